@@ -1,26 +1,59 @@
 package main
 
 import (
+	"context"
+	// Часовые пояса пользователей проверяются и без zoneinfo в образе
+	_ "time/tzdata"
+
 	_ "speech-processing-service/docs"
 	"speech-processing-service/internal/app"
 	"speech-processing-service/internal/config"
-	"speech-processing-service/internal/drivers/apis/deepgram"
-	"speech-processing-service/internal/drivers/apis/gemini"
+	"speech-processing-service/internal/drivers/apis/llm"
+	"speech-processing-service/internal/drivers/apis/stt"
 	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/drivers/tools/ffmpeg"
+	"speech-processing-service/internal/drivers/tools/jwt"
 	"speech-processing-service/internal/drivers/tools/minio"
+	"speech-processing-service/internal/drivers/tools/password"
+	"speech-processing-service/internal/drivers/tools/prompts"
+	"speech-processing-service/internal/entity"
 	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/jobs"
+	"speech-processing-service/internal/recording"
+	"speech-processing-service/internal/usecases/accept_word_suggestion"
 	"speech-processing-service/internal/usecases/add_word_to_collection"
+	"speech-processing-service/internal/usecases/assess_read_aloud"
 	"speech-processing-service/internal/usecases/attach_answer_to_session"
+	"speech-processing-service/internal/usecases/complete_session_job"
 	"speech-processing-service/internal/usecases/create_word_collection"
+	"speech-processing-service/internal/usecases/delete_user_words"
 	"speech-processing-service/internal/usecases/delete_word_collection"
+	"speech-processing-service/internal/usecases/enqueue_session_completion"
 	"speech-processing-service/internal/usecases/get_all_topics"
 	"speech-processing-service/internal/usecases/get_article_by_id"
 	"speech-processing-service/internal/usecases/get_articles"
 	"speech-processing-service/internal/usecases/get_collection_detail"
+	"speech-processing-service/internal/usecases/get_grammar_mistakes"
+	"speech-processing-service/internal/usecases/get_job"
+	"speech-processing-service/internal/usecases/get_review_queue"
+	"speech-processing-service/internal/usecases/get_session_detail"
 	"speech-processing-service/internal/usecases/get_topic_questions"
 	"speech-processing-service/internal/usecases/get_user_collections"
+	"speech-processing-service/internal/usecases/get_user_progress"
+	"speech-processing-service/internal/usecases/get_user_sessions"
+	"speech-processing-service/internal/usecases/get_user_settings"
+	"speech-processing-service/internal/usecases/get_word_suggestions"
+	"speech-processing-service/internal/usecases/login_user"
+	"speech-processing-service/internal/usecases/preview_prompt"
+	"speech-processing-service/internal/usecases/register_user"
+	"speech-processing-service/internal/usecases/review_word"
 	"speech-processing-service/internal/usecases/session_completer"
 	"speech-processing-service/internal/usecases/start_session"
+	"speech-processing-service/internal/usecases/stream_answer"
+	"speech-processing-service/internal/usecases/transfer_user_word"
+	"speech-processing-service/internal/usecases/update_user_settings"
+	"speech-processing-service/internal/usecases/update_user_word"
+	"speech-processing-service/internal/usecases/update_word_collection"
 
 	"go.uber.org/zap"
 
@@ -28,10 +61,17 @@ import (
 )
 
 type drivers struct {
-	storage  *storage.Storage
-	minio    *minio.Minio
-	deepgram *deepgram.Deepgram
-	gemini   *gemini.Gemini
+	storage *storage.Storage
+	minio   *minio.Minio
+	stt     stt.Transcriber
+	// streams is nil when the stt provider can't transcribe live audio
+	streams stt.StreamTranscriber
+	llm     llm.Analyzer
+	jwt     *jwt.JWT
+	hasher  *password.Hasher
+	prompts *prompts.Prompts
+	// ffmpeg is nil when answer normalization is disabled
+	ffmpeg *ffmpeg.FFmpeg
 }
 
 func newDrivers(cfg *config.Config) (drivers, error) {
@@ -45,15 +85,53 @@ func newDrivers(cfg *config.Config) (drivers, error) {
 		return drivers{}, errs.Wrap("minio.New", err)
 	}
 
-	deepgram := deepgram.New(cfg.Deepgram)
+	transcriber, err := stt.New(cfg, &minio)
+	if err != nil {
+		return drivers{}, errs.Wrap("stt.New", err)
+	}
+
+	streams, err := stt.NewStreaming(cfg, &minio)
+	if err != nil {
+		return drivers{}, errs.Wrap("stt.NewStreaming", err)
+	}
+
+	analyzer, err := llm.New(cfg)
+	if err != nil {
+		return drivers{}, errs.Wrap("llm.New", err)
+	}
+
+	jwt, err := jwt.New(cfg.Auth)
+	if err != nil {
+		return drivers{}, errs.Wrap("jwt.New", err)
+	}
+
+	hasher := password.New()
+
+	prompts, err := prompts.New(cfg.Prompts)
+	if err != nil {
+		return drivers{}, errs.Wrap("prompts.New", err)
+	}
+
+	var normalizer *ffmpeg.FFmpeg
+	if cfg.Answers.FFmpegBinary != "" {
+		ffmpeg, err := ffmpeg.New(cfg.Answers)
+		if err != nil {
+			return drivers{}, errs.Wrap("ffmpeg.New", err)
+		}
 
-	gemini := gemini.New(cfg.Gemini)
+		normalizer = &ffmpeg
+	}
 
 	return drivers{
-		storage:  &storage,
-		minio:    &minio,
-		deepgram: &deepgram,
-		gemini:   &gemini,
+		storage: &storage,
+		minio:   &minio,
+		stt:     transcriber,
+		streams: streams,
+		llm:     analyzer,
+		jwt:     &jwt,
+		hasher:  &hasher,
+		prompts: &prompts,
+		ffmpeg:  normalizer,
 	}, nil
 }
 
@@ -62,6 +140,8 @@ type UseCases struct {
 	topicsQuestionsGetter *get_topic_questions.Usecase
 	sessionStarter        *start_session.Usecase
 	answerAttacher        *attach_answer_to_session.UseCase
+	answerStreamer        *stream_answer.UseCase
+	readAloudAssessor     *assess_read_aloud.UseCase
 	sessionCompleter      *session_completer.UseCase
 	articlesGetter        *get_articles.UseCase
 	articleByIDGetter     *get_article_by_id.UseCase
@@ -70,27 +150,110 @@ type UseCases struct {
 	getUserCollections    *get_user_collections.UseCase
 	getCollectionDetail   *get_collection_detail.UseCase
 	addWordToCollection   *add_word_to_collection.UseCase
+	registerUser          *register_user.UseCase
+	loginUser             *login_user.UseCase
+	userSessionsGetter    *get_user_sessions.UseCase
+	sessionDetailGetter   *get_session_detail.UseCase
+	completionEnqueuer    *enqueue_session_completion.UseCase
+	completeSessionJob    *complete_session_job.UseCase
+	jobGetter             *get_job.UseCase
+	promptPreviewer       *preview_prompt.UseCase
+	userSettingsGetter    *get_user_settings.UseCase
+	userSettingsUpdater   *update_user_settings.UseCase
+	userProgressGetter    *get_user_progress.UseCase
+	grammarMistakesGetter *get_grammar_mistakes.UseCase
+	reviewQueueGetter     *get_review_queue.UseCase
+	wordReviewer          *review_word.UseCase
+	suggestionsGetter     *get_word_suggestions.UseCase
+	suggestionAccepter    *accept_word_suggestion.UseCase
+	wordUpdater           *update_user_word.UseCase
+	wordsDeleter          *delete_user_words.UseCase
+	wordTransferrer       *transfer_user_word.UseCase
+	collectionUpdater     *update_word_collection.UseCase
 }
 
-func newUseCases(logger *zap.Logger, drivers *drivers) UseCases {
+func newUseCases(logger *zap.Logger, cfg *config.Config, drivers *drivers) UseCases {
 	allTopicsGetter := get_all_topics.New(logger, drivers.storage, drivers.minio)
 	topicsQuestionsGetter := get_topic_questions.New(logger, drivers.storage)
 	sessionStarter := start_session.New(logger, drivers.storage, drivers.storage)
-	answerAttacher := attach_answer_to_session.New(logger, drivers.minio, drivers.storage)
-	sessionCompleter := session_completer.New(logger, drivers.storage, drivers.minio, drivers.deepgram, drivers.gemini)
+	// Nil-указатель в интерфейсе не равен nil, поэтому нормализатор передается только если он включен
+	var normalizer recording.Normalizer
+	if drivers.ffmpeg != nil {
+		normalizer = drivers.ffmpeg
+	}
+
+	inspector := recording.New(cfg.Answers, normalizer)
+
+	answerAttacher := attach_answer_to_session.New(
+		logger,
+		drivers.minio,
+		drivers.storage,
+		drivers.storage,
+		&inspector,
+	)
+	answerStreamer := stream_answer.New(
+		logger,
+		drivers.storage,
+		drivers.streams,
+		&answerAttacher,
+		drivers.storage,
+		cfg.Answers,
+	)
+	readAloudAssessor := assess_read_aloud.New(logger, drivers.storage, drivers.minio, drivers.stt, &inspector)
+	sessionCompleter := session_completer.New(
+		logger,
+		drivers.storage,
+		drivers.storage,
+		drivers.storage,
+		drivers.minio,
+		drivers.stt,
+		drivers.llm,
+		drivers.prompts,
+		cfg.TranscriptionConcurrency,
+		cfg.LLM.Retries,
+	)
 	articlesGetter := get_articles.New(drivers.storage, drivers.minio)
 	articleByIDGetter := get_article_by_id.New(drivers.storage, drivers.minio)
 	createWordCollection := create_word_collection.New(drivers.storage, drivers.minio, drivers.minio)
-	deleteWordCollection := delete_word_collection.New(drivers.storage)
+	deleteWordCollection := delete_word_collection.New(logger, drivers.storage, drivers.minio)
 	getUserCollections := get_user_collections.New(drivers.storage, drivers.minio)
 	getCollectionDetail := get_collection_detail.New(drivers.storage, drivers.minio)
 	addWordToCollection := add_word_to_collection.New(drivers.storage)
+	registerUser := register_user.New(drivers.storage, drivers.hasher, drivers.jwt)
+	loginUser := login_user.New(drivers.storage, drivers.hasher, drivers.jwt)
+	userSessionsGetter := get_user_sessions.New(drivers.storage)
+	sessionDetailGetter := get_session_detail.New(logger, drivers.storage, drivers.minio)
+	completionEnqueuer := enqueue_session_completion.New(drivers.storage, drivers.prompts, cfg.Jobs.MaxAttempts)
+	completeSessionJob := complete_session_job.New(&sessionCompleter)
+	jobGetter := get_job.New(drivers.storage)
+	promptPreviewer := preview_prompt.New(drivers.prompts)
+	userSettingsGetter := get_user_settings.New(drivers.storage)
+	userSettingsUpdater := update_user_settings.New(drivers.storage)
+	userProgressGetter := get_user_progress.New(logger, drivers.storage)
+	grammarMistakesGetter := get_grammar_mistakes.New(drivers.storage, drivers.minio)
+	reviewQueueGetter := get_review_queue.New(drivers.storage)
+	wordReviewer := review_word.New(drivers.storage, cfg.Reviews)
+	suggestionsGetter := get_word_suggestions.New(
+		logger,
+		drivers.storage,
+		drivers.llm,
+		drivers.prompts,
+		cfg.Suggestions,
+		cfg.LLM.Retries,
+	)
+	suggestionAccepter := accept_word_suggestion.New(drivers.storage)
+	wordUpdater := update_user_word.New(drivers.storage)
+	wordsDeleter := delete_user_words.New(drivers.storage)
+	wordTransferrer := transfer_user_word.New(drivers.storage)
+	collectionUpdater := update_word_collection.New(logger, drivers.storage, drivers.minio, drivers.minio)
 
 	return UseCases{
 		allTopicsGetter:       &allTopicsGetter,
 		topicsQuestionsGetter: &topicsQuestionsGetter,
 		sessionStarter:        &sessionStarter,
 		answerAttacher:        &answerAttacher,
+		answerStreamer:        &answerStreamer,
+		readAloudAssessor:     &readAloudAssessor,
 		sessionCompleter:      &sessionCompleter,
 		articlesGetter:        &articlesGetter,
 		articleByIDGetter:     &articleByIDGetter,
@@ -99,12 +262,38 @@ func newUseCases(logger *zap.Logger, drivers *drivers) UseCases {
 		getUserCollections:    &getUserCollections,
 		getCollectionDetail:   &getCollectionDetail,
 		addWordToCollection:   &addWordToCollection,
+		registerUser:          &registerUser,
+		loginUser:             &loginUser,
+		userSessionsGetter:    &userSessionsGetter,
+		sessionDetailGetter:   &sessionDetailGetter,
+		completionEnqueuer:    &completionEnqueuer,
+		completeSessionJob:    &completeSessionJob,
+		jobGetter:             &jobGetter,
+		promptPreviewer:       &promptPreviewer,
+		userSettingsGetter:    &userSettingsGetter,
+		userSettingsUpdater:   &userSettingsUpdater,
+		userProgressGetter:    &userProgressGetter,
+		grammarMistakesGetter: &grammarMistakesGetter,
+		reviewQueueGetter:     &reviewQueueGetter,
+		wordReviewer:          &wordReviewer,
+		suggestionsGetter:     &suggestionsGetter,
+		suggestionAccepter:    &suggestionAccepter,
+		wordUpdater:           &wordUpdater,
+		wordsDeleter:          &wordsDeleter,
+		wordTransferrer:       &wordTransferrer,
+		collectionUpdater:     &collectionUpdater,
 	}
 }
 
 // @title Speech Processing Service API
 // @version 1.0
 // @description This is a sample server for speech processing.
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name X-Admin-Token
 func main() {
 	loggerConfig := zap.NewProductionConfig()
 	loggerConfig.DisableStacktrace = true
@@ -130,14 +319,22 @@ func main() {
 		return
 	}
 
-	usecases := newUseCases(logger, &drivers)
+	usecases := newUseCases(logger, &cfg, &drivers)
+
+	jobsPool := jobs.New(logger, drivers.storage, cfg.Jobs)
+	jobsPool.Register(entity.JobTypeCompleteSession, usecases.completeSessionJob)
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobsPool.Start(jobsCtx)
 
 	application := app.New(
 		usecases.allTopicsGetter,
 		usecases.topicsQuestionsGetter,
 		usecases.sessionStarter,
 		usecases.answerAttacher,
-		usecases.sessionCompleter,
+		usecases.answerStreamer,
+		usecases.readAloudAssessor,
+		usecases.completionEnqueuer,
 		usecases.articlesGetter,
 		usecases.articleByIDGetter,
 		usecases.createWordCollection,
@@ -145,10 +342,32 @@ func main() {
 		usecases.getUserCollections,
 		usecases.getCollectionDetail,
 		usecases.addWordToCollection,
+		usecases.registerUser,
+		usecases.loginUser,
+		drivers.jwt,
+		usecases.userSessionsGetter,
+		usecases.sessionDetailGetter,
+		usecases.jobGetter,
+		usecases.promptPreviewer,
+		usecases.userSettingsGetter,
+		usecases.userSettingsUpdater,
+		usecases.userProgressGetter,
+		usecases.grammarMistakesGetter,
+		usecases.reviewQueueGetter,
+		usecases.wordReviewer,
+		usecases.suggestionsGetter,
+		usecases.suggestionAccepter,
+		usecases.wordUpdater,
+		usecases.wordsDeleter,
+		usecases.wordTransferrer,
+		usecases.collectionUpdater,
 		&cfg,
 		logger,
 	)
 	// TODO: fix this
 	application.InitREST()
 	application.Run()
+
+	stopJobs()
+	jobsPool.Wait()
 }
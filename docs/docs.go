@@ -15,6 +15,111 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/prompts/preview": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Render a prompt with the given answers exactly as it would be sent to the model. The stored\ntemplate is selected by name and version (the latest by default); a template in the body is\nrendered instead, to check a new version before adding it. Only session_analysis can be previewed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Preview prompt",
+                "parameters": [
+                    {
+                        "description": "Prompt and sample answers",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.PreviewPromptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.PreviewPromptResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/articles": {
             "get": {
                 "description": "Returns a list of article previews with pagination support",
@@ -128,149 +233,279 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections": {
-            "get": {
-                "description": "Get all word collections for the authenticated user",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "collections"
-                ],
-                "summary": "Get user collections",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/views.GetUserCollectionsResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/views.ErrorResponse"
-                        }
-                    }
-                }
-            },
+        "/auth/login": {
             "post": {
-                "description": "Create a new word collection with optional image",
+                "description": "Exchange email and password for a bearer token",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "collections"
+                    "auth"
                 ],
-                "summary": "Create word collection",
+                "summary": "Login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Collection name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Collection image",
-                        "name": "image",
-                        "in": "formData"
+                        "description": "Credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.AuthRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.CreateWordCollectionResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/views.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/views.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/collections/{id}": {
-            "get": {
-                "description": "Get full information about a specific collection including user words and AI suggestions",
+        "/auth/register": {
+            "post": {
+                "description": "Create a new user account and return a bearer token",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "collections"
+                    "auth"
                 ],
-                "summary": "Get collection detail",
+                "summary": "Register user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Collection ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.AuthRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.WordCollectionDetailResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/views.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/views.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/views.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete a word collection by ID",
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/collections": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all word collections for the authenticated user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "collections"
                 ],
-                "summary": "Delete word collection",
+                "summary": "Get user collections",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.GetUserCollectionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/views.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/views.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new word collection with optional image",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Create word collection",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Collection ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Collection name",
+                        "name": "name",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Collection image: JPEG, PNG, GIF or WebP up to 10 MB",
+                        "name": "image",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.SuccessResponse"
+                            "$ref": "#/definitions/views.CreateWordCollectionResponse"
                         }
                     },
                     "400": {
@@ -279,8 +514,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/views.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/views.ErrorResponse"
                         }
@@ -294,19 +529,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{id}/words": {
-            "post": {
-                "description": "Add a new word to a collection",
-                "consumes": [
-                    "application/json"
+        "/collections/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get full information about a specific collection including user words and AI suggestions",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "collections"
                 ],
-                "summary": "Add word to collection",
+                "summary": "Get collection detail",
                 "parameters": [
                     {
                         "type": "string",
@@ -314,22 +551,69 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.WordCollectionDetailResponse"
+                        }
                     },
-                    {
-                        "description": "Word data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/views.AddWordToCollectionRequest"
+                            "$ref": "#/definitions/views.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/views.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/views.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/views.ErrorResponse"
                         }
                     }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a word collection by ID together with its cover image",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Delete word collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.AddWordToCollectionResponse"
+                            "$ref": "#/definitions/views.SuccessResponse"
                         }
                     },
                     "400": {
@@ -338,6 +622,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/views.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/views.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -351,42 +641,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/session/{sessionID}/answer": {
-            "post": {
-                "description": "Attach an answer to a session",
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename the collection and replace or remove its cover image. Accepts multipart/form-data with\noptional name, image and remove_image fields, or JSON views.UpdateWordCollectionRequest without an image.\nThe previous image is deleted from storage",
                 "consumes": [
-                    "multipart/form-data"
+                    "multipart/form-data",
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "session"
+                    "collections"
                 ],
-                "summary": "Attach answer to session",
+                "summary": "Update word collection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Session ID",
-                        "name": "sessionID",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Question ID",
-                        "name": "questionID",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "New collection name",
+                        "name": "name",
+                        "in": "formData"
                     },
                     {
                         "type": "file",
-                        "description": "Answer file",
-                        "name": "answer",
-                        "in": "formData",
-                        "required": true
+                        "description": "New cover image: JPEG, PNG, GIF or WebP up to 10 MB",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove the cover image",
+                        "name": "remove_image",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -401,7 +699,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/views.UpdateWordCollectionResponse"
                                         }
                                     }
                                 }
@@ -426,6 +724,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -465,23 +781,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/session/{sessionID}/complete": {
-            "post": {
-                "description": "Complete a session",
+        "/collections/{id}/review": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the words of the collection due for review, the longest overdue first. New words are due\nright after they are added; the next review date of a word is set by POST /words/{wordID}/review",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "session"
+                    "collections"
                 ],
-                "summary": "Complete session",
+                "summary": "Get review queue",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Session ID",
-                        "name": "sessionID",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of words to return",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -496,7 +824,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/views.CompleteSessionResp"
+                                            "$ref": "#/definitions/views.ReviewQueueResponse"
                                         }
                                     }
                                 }
@@ -520,29 +848,86 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/sessions": {
-            "post": {
-                "description": "Start a new session",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "session"
-                ],
-                "summary": "Start session",
-                "parameters": [
-                    {
-                        "description": "Session data",
-                        "name": "session",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/views.StartSessionRequest"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get new words for the collection suggested by the AI from the words it already has. The suggestions\nare cached and generated again when they are older than the TTL, which takes a few seconds",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Get word suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -557,7 +942,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/views.StartSessionResponse"
+                                            "$ref": "#/definitions/views.WordSuggestionsResponse"
                                         }
                                     }
                                 }
@@ -582,6 +967,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -621,16 +1024,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/topics": {
-            "get": {
-                "description": "Get a list of all topics",
+        "/collections/{id}/suggestions/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a suggested word with its translation to the collection and remove it from the suggestions",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "topics"
+                    "collections"
+                ],
+                "summary": "Accept word suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suggested word",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.AcceptWordSuggestionRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all topics",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -643,7 +1072,79 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/views.GetAllTopicsResponse"
+                                            "$ref": "#/definitions/views.AddWordToCollectionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
                                         }
                                     }
                                 }
@@ -671,16 +1172,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/topics/{topicID}/questions": {
-            "get": {
-                "description": "Get a list of questions by topic",
+        "/collections/{id}/suggestions/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate new word suggestions for the collection regardless of the age of the cached ones",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "topics"
+                    "collections"
+                ],
+                "summary": "Refresh word suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get questions by topic",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -693,7 +1208,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/views.GetTopicQuestionsResponse"
+                                            "$ref": "#/definitions/views.WordSuggestionsResponse"
                                         }
                                     }
                                 }
@@ -718,6 +1233,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -756,279 +1289,3327 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "views.AISuggestionDTO": {
-            "type": "object",
-            "properties": {
-                "reason": {
-                    "type": "string"
-                },
-                "translation": {
-                    "type": "string"
-                },
-                "word": {
-                    "type": "string"
-                }
-            }
-        },
-        "views.AddWordToCollectionRequest": {
-            "type": "object",
-            "properties": {
-                "example": {
-                    "type": "string"
-                },
-                "translation": {
-                    "type": "string"
-                },
-                "word": {
-                    "type": "string"
-                }
-            }
         },
-        "views.AddWordToCollectionResponse": {
-            "type": "object",
-            "properties": {
-                "word": {
+        "/collections/{id}/words": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new word to a collection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Add word to collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Word data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.AddWordToCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.AddWordToCollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/views.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/views.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/views.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/views.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/words/bulk-delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete several words from the collection at once. Words that are already deleted or belong to\nother collections are skipped; the response has the number of deleted words",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Delete words",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "IDs of the words to delete, at most 500",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.DeleteUserWordsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.DeleteUserWordsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/words/{wordID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a word from the collection together with its review history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Delete word",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Word ID (UUID)",
+                        "name": "wordID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fix the word, translation or example of a word in the collection; missing fields keep their values\nand an empty example removes it. The review schedule of the word is kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Update word",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Word ID (UUID)",
+                        "name": "wordID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.UpdateUserWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.UserWordResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/words/{wordID}/copy": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a copy of a word to another collection of the user. The copy is studied from the beginning",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Copy word",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Word ID (UUID)",
+                        "name": "wordID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target collection",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.TransferUserWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.UserWordResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/words/{wordID}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a word to another collection of the user. The word keeps its review schedule, its review\nhistory stays with the source collection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Move word",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Word ID (UUID)",
+                        "name": "wordID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target collection",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.TransferUserWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.UserWordResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{jobID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get background job status, progress and result",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "jobID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.JobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/grammar-mistakes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rank the grammar rule categories the user breaks most often in session answers. Every category\nhas the latest examples and the articles covering the rule, closest to the user's level first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get recurring grammar mistakes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of categories to return",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.GrammarMistakesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/progress": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the metrics of the last completed sessions, oldest first, with the current level estimated\nover the recent sessions and the trend of every skill: level, grammar (issues per answer),\nvocabulary (mean level of the top words), fluency (words per minute) and fillers (share of words)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get progress",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of sessions in the series",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.ProgressResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the settings of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.UserSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the settings of the authenticated user; missing fields keep their values.\nfeedback_language is the language of explanations and feedback in session analyses,\ntime_zone (IANA name) sets the calendar days of study streaks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user settings",
+                "parameters": [
+                    {
+                        "description": "Settings to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.UpdateUserSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.UserSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/read-aloud": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compares the recording of a text read aloud with the text and reports omitted, inserted and\nmispronounced words with per-word accuracy. The text is given directly, as the example of a\ncollection word or as a sentence of an article; exactly one source is required",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "read-aloud"
+                ],
+                "summary": "Assess reading aloud",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Recording",
+                        "name": "audio",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reference text",
+                        "name": "text",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection word whose example is read",
+                        "name": "word_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article whose sentence is read",
+                        "name": "article_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Zero-based sentence index in the article",
+                        "name": "sentence",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.ReadAloudResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/session/{sessionID}/answer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach an answer to a session. Accepted formats: wav, mp3, aac, m4a, ogg, flac, webm;\nthe format is detected from the file content, size and duration are limited\nThe question must belong to the session topic; a repeated upload replaces the previous answer",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "Attach answer to session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "questionID",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Answer file",
+                        "name": "answer",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/session/{sessionID}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedule session completion and return the job to poll via GET /jobs/{jobID}. A completed session\nresolves to the stored analysis; reanalyze=true or another prompt regenerates it from the stored\ntranscripts. By default the latest version of the session_analysis prompt is used and the feedback\nis written in the language from the user settings. While a completion is queued or running it is\nreturned again, or 409 is returned if it uses another prompt or language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "Complete session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Regenerate the analysis",
+                        "name": "reanalyze",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "session_analysis",
+                        "description": "Prompt name",
+                        "name": "prompt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prompt version, e.g. v2; the latest by default",
+                        "name": "prompt_version",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru",
+                            "be"
+                        ],
+                        "type": "string",
+                        "description": "Language of explanations and feedback; the user setting by default",
+                        "name": "feedback_language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.JobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's speaking sessions, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "Get session history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of sessions to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of sessions to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.GetUserSessionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start a new session",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "Start session",
+                "parameters": [
+                    {
+                        "description": "Session data",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.StartSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.StartSessionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a session with its answers, audio URLs and stored analysis",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "Get session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.SessionDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}/answer/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "WebSocket endpoint for live practice. Send the recording in binary messages and {\"type\":\"finish\"}\nwhen done; the server replies with interim and final transcripts and, once the answer is stored,\na \"saved\" message with the answer ID. Disconnecting, or sending nothing for longer than the idle\ntimeout, also stores what was recorded; recordings over the size or duration limit are rejected.\nBrowsers may pass the access token in the token query parameter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "Stream an answer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "questionID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Access token, if the Authorization header can't be set",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/views.StreamMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/topics": {
+            "get": {
+                "description": "Get a list of all topics",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "topics"
+                ],
+                "summary": "Get all topics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.GetAllTopicsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{topicID}/questions": {
+            "get": {
+                "description": "Get a list of questions by topic",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "topics"
+                ],
+                "summary": "Get questions by topic",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.GetTopicQuestionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/words/{wordID}/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record how well the user recalled the word and schedule its next review with the SM-2 algorithm.\nA grade below 3 is a lapse: the word comes back the next day. The word is learned while its\ninterval reaches the mastery threshold, and the review continues the study streak of the collection\nby calendar day in the user's time zone. The grade is applied to the state the word had when\nthe request was read, so a repeated submission of the same review returns 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Review word",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Word ID (UUID)",
+                        "name": "wordID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recall grade from 0 to 5",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.ReviewWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/views.ReviewWordResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/views.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/views.Error"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "views.AISuggestionDTO": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "translation": {
+                    "type": "string"
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.AcceptWordSuggestionRequest": {
+            "type": "object",
+            "properties": {
+                "word": {
+                    "type": "string",
+                    "example": "itinerary"
+                }
+            }
+        },
+        "views.AddWordToCollectionRequest": {
+            "type": "object",
+            "properties": {
+                "example": {
+                    "type": "string"
+                },
+                "translation": {
+                    "type": "string"
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.AddWordToCollectionResponse": {
+            "type": "object",
+            "properties": {
+                "word": {
                     "$ref": "#/definitions/views.UserWordDTO"
                 }
             }
         },
-        "views.ArticleData": {
+        "views.AnswerFeedbackDTO": {
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "feedback": {
+                    "type": "string",
+                    "example": "You answered the question, but mixed up the tenses."
+                },
+                "grammar_issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.GrammarIssueDTO"
+                    }
+                },
+                "level": {
+                    "type": "string",
+                    "example": "B1"
+                },
+                "question": {
+                    "type": "string",
+                    "example": "What was your last trip?"
+                },
+                "question_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "relevance": {
+                    "type": "string",
+                    "enum": [
+                        "relevant",
+                        "partially_relevant",
+                        "off_topic"
+                    ],
+                    "example": "relevant"
+                },
+                "transcript": {
+                    "type": "string",
+                    "example": "I have went to Spain last year."
+                }
+            }
+        },
+        "views.ArticleData": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "$ref": "#/definitions/views.ArticleDetails"
+                }
+            }
+        },
+        "views.ArticleDetails": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.GrammarRuleItem"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "vocabulary": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.VocabularyWord"
+                    }
+                }
+            }
+        },
+        "views.ArticlePreview": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "minutes_to_read": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.ArticlesPreviewData": {
+            "type": "object",
+            "properties": {
+                "articles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.ArticlePreview"
+                    }
+                }
+            }
+        },
+        "views.AuthRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "learner@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret123"
+                }
+            }
+        },
+        "views.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/views.UserDTO"
+                }
+            }
+        },
+        "views.CompleteSessionResp": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.AnswerFeedbackDTO"
+                    }
+                },
+                "feedback_language": {
+                    "description": "FeedbackLanguage is the language of the explanations and feedback",
+                    "type": "string",
+                    "example": "ru"
+                },
+                "fluency": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.FluencyDTO"
+                    }
+                },
+                "grammar_issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.GrammarIssueDTO"
+                    }
+                },
+                "overall_feedback": {
+                    "type": "string"
+                },
+                "overall_level": {
+                    "type": "string"
+                },
+                "prompt": {
+                    "$ref": "#/definitions/views.PromptVersionDTO"
+                },
+                "rephrase_suggestions": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "original": {
+                                "type": "string"
+                            },
+                            "suggestion": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "top_words": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "level": {
+                                "type": "string"
+                            },
+                            "word": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "views.CreateWordCollectionResponse": {
             "type": "object",
             "properties": {
-                "article": {
-                    "$ref": "#/definitions/views.ArticleDetails"
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "collection": {
+                            "$ref": "#/definitions/views.WordCollectionResponse"
+                        }
+                    }
                 }
             }
         },
-        "views.ArticleDetails": {
+        "views.DeleteUserWordsRequest": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "id": {
+                "word_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "views.DeleteUserWordsResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "views.Error": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "integer"
                 },
-                "image_url": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "views.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/views.Error"
+                }
+            }
+        },
+        "views.FluencyDTO": {
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "integer",
+                    "example": 12
                 },
-                "level": {
-                    "type": "string"
+                "filler_count": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "minutes": {
-                    "type": "integer"
+                "filler_rate": {
+                    "type": "number",
+                    "example": 0.04
                 },
-                "rules": {
+                "longest_pause_seconds": {
+                    "type": "number",
+                    "example": 1.6
+                },
+                "low_confidence_words": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/views.GrammarRuleItem"
+                        "$ref": "#/definitions/views.LowConfidenceWordDTO"
                     }
                 },
-                "title": {
-                    "type": "string"
+                "pause_count": {
+                    "type": "integer",
+                    "example": 4
                 },
-                "vocabulary": {
+                "question_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "speaking_seconds": {
+                    "type": "number",
+                    "example": 41.5
+                },
+                "total_pause_seconds": {
+                    "type": "number",
+                    "example": 3.8
+                },
+                "words_count": {
+                    "type": "integer",
+                    "example": 84
+                },
+                "words_per_minute": {
+                    "type": "number",
+                    "example": 121.45
+                }
+            }
+        },
+        "views.GetAllTopicsResponse": {
+            "type": "object",
+            "properties": {
+                "topics": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/views.VocabularyWord"
+                        "type": "object",
+                        "properties": {
+                            "description": {
+                                "type": "string"
+                            },
+                            "id": {
+                                "type": "integer"
+                            },
+                            "photo_url": {
+                                "type": "string"
+                            },
+                            "title": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "views.ArticlePreview": {
+        "views.GetTopicQuestionsResponse": {
+            "type": "object",
+            "properties": {
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Question"
+                    }
+                }
+            }
+        },
+        "views.GetUserCollectionsResponse": {
+            "type": "object",
+            "properties": {
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.WordCollectionResponse"
+                    }
+                }
+            }
+        },
+        "views.GetUserSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.SessionSummaryDTO"
+                    }
+                }
+            }
+        },
+        "views.GrammarIssueDTO": {
             "type": "object",
             "properties": {
+                "answer_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "category": {
+                    "type": "string",
+                    "example": "tenses"
+                },
+                "corrected_sentence": {
+                    "type": "string",
+                    "example": "I went there last year."
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "Past Simple is used for finished actions at a stated time."
+                },
+                "sentence": {
+                    "type": "string",
+                    "example": "I have went there last year."
+                }
+            }
+        },
+        "views.GrammarMistakeCategoryDTO": {
+            "type": "object",
+            "properties": {
+                "articles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.ArticlePreview"
+                    }
+                },
+                "category": {
+                    "type": "string",
+                    "example": "tenses"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "examples": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.GrammarMistakeDTO"
+                    }
+                },
+                "last_seen_at": {
+                    "type": "string",
+                    "example": "2025-12-01T17:00:00Z"
+                },
+                "sessions_count": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "views.GrammarMistakeDTO": {
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "corrected_sentence": {
+                    "type": "string",
+                    "example": "I went there last year."
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-12-01T17:00:00Z"
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "Past Simple is used for finished actions at a stated time."
+                },
+                "sentence": {
+                    "type": "string",
+                    "example": "I have went there last year."
+                },
+                "session_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "views.GrammarMistakesResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.GrammarMistakeCategoryDTO"
+                    }
+                }
+            }
+        },
+        "views.GrammarRuleItem": {
+            "type": "object",
+            "properties": {
+                "example": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "image_url": {
+                "name": {
                     "type": "string"
                 },
-                "level": {
+                "note": {
                     "type": "string"
+                }
+            }
+        },
+        "views.JobDTO": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "minutes_to_read": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
-                "title": {
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "progress": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "result": {
+                    "$ref": "#/definitions/views.CompleteSessionResp"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "running"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "complete_session"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "views.ArticlesPreviewData": {
+        "views.JobResponse": {
             "type": "object",
             "properties": {
-                "articles": {
+                "job": {
+                    "$ref": "#/definitions/views.JobDTO"
+                }
+            }
+        },
+        "views.LowConfidenceWordDTO": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number",
+                    "example": 0.42
+                },
+                "start": {
+                    "type": "number",
+                    "example": 3.12
+                },
+                "word": {
+                    "type": "string",
+                    "example": "through"
+                }
+            }
+        },
+        "views.PreviewPromptRequest": {
+            "type": "object",
+            "properties": {
+                "answers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/views.ArticlePreview"
+                        "$ref": "#/definitions/views.PromptAnswerRequest"
                     }
+                },
+                "feedback_language": {
+                    "type": "string",
+                    "example": "ru"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "session_analysis"
+                },
+                "template": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v2"
                 }
             }
         },
-        "views.CompleteSessionResp": {
+        "views.PreviewPromptResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "session_analysis"
+                },
+                "prompt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v1"
+                }
+            }
+        },
+        "views.ProgressPointDTO": {
             "type": "object",
             "properties": {
+                "answers_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2025-12-01T17:00:00Z"
+                },
+                "filler_rate": {
+                    "type": "number",
+                    "example": 0.032
+                },
                 "grammar_issues": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "corrected_sentence": {
-                                "type": "string"
-                            },
-                            "explanation": {
-                                "type": "string"
-                            },
-                            "sentence": {
-                                "type": "string"
-                            }
-                        }
+                    "type": "integer",
+                    "example": 4
+                },
+                "grammar_issues_per_answer": {
+                    "type": "number",
+                    "example": 1.333
+                },
+                "level_score": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "overall_level": {
+                    "type": "string",
+                    "example": "B1"
+                },
+                "session_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "vocabulary": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
                     }
                 },
-                "overall_feedback": {
-                    "type": "string"
+                "vocabulary_score": {
+                    "type": "number",
+                    "example": 3.5
                 },
-                "overall_level": {
-                    "type": "string"
+                "words_per_minute": {
+                    "type": "number",
+                    "example": 118.4
+                }
+            }
+        },
+        "views.ProgressResponse": {
+            "type": "object",
+            "properties": {
+                "current_level": {
+                    "type": "string",
+                    "example": "B1"
                 },
-                "rephrase_suggestions": {
+                "current_score": {
+                    "type": "number",
+                    "example": 3.4
+                },
+                "series": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "original": {
-                                "type": "string"
-                            },
-                            "suggestion": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/views.ProgressPointDTO"
                     }
                 },
-                "top_words": {
+                "sessions_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "trends": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "level": {
-                                "type": "string"
-                            },
-                            "word": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/views.SkillTrendDTO"
                     }
                 }
             }
         },
-        "views.CreateWordCollectionResponse": {
+        "views.PromptAnswerRequest": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object",
-                    "properties": {
-                        "collection": {
-                            "$ref": "#/definitions/views.WordCollectionResponse"
-                        }
-                    }
+                "answer_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "question": {
+                    "type": "string",
+                    "example": "What was your last trip?"
+                },
+                "transcript": {
+                    "type": "string",
+                    "example": "I have went to Spain last year."
                 }
             }
         },
-        "views.Error": {
+        "views.PromptVersionDTO": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "session_analysis"
                 },
-                "message": {
-                    "type": "string"
+                "version": {
+                    "type": "string",
+                    "example": "v1"
                 }
             }
         },
-        "views.ErrorResponse": {
+        "views.Question": {
             "type": "object",
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/views.Error"
+                "id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
                 }
             }
         },
-        "views.GetAllTopicsResponse": {
+        "views.ReadAloudResponse": {
             "type": "object",
             "properties": {
-                "topics": {
+                "accuracy": {
+                    "type": "number",
+                    "example": 81.4
+                },
+                "insertions": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "omissions": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "The quick brown fox jumps over the lazy dog."
+                },
+                "substitutions": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "transcript": {
+                    "type": "string",
+                    "example": "The quick brawn fox jumps over lazy dog."
+                },
+                "words": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "description": {
-                                "type": "string"
-                            },
-                            "id": {
-                                "type": "integer"
-                            },
-                            "photo_url": {
-                                "type": "string"
-                            },
-                            "title": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/views.ReadAloudWordDTO"
                     }
                 }
             }
         },
-        "views.GetTopicQuestionsResponse": {
+        "views.ReadAloudWordDTO": {
             "type": "object",
             "properties": {
-                "questions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/views.Question"
-                    }
+                "accuracy": {
+                    "type": "number",
+                    "example": 72
+                },
+                "end": {
+                    "type": "number",
+                    "example": 1.56
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "brown"
+                },
+                "spoken": {
+                    "type": "string",
+                    "example": "brawn"
+                },
+                "start": {
+                    "type": "number",
+                    "example": 1.2
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "correct",
+                        "substituted",
+                        "omitted",
+                        "inserted"
+                    ],
+                    "example": "substituted"
                 }
             }
         },
-        "views.GetUserCollectionsResponse": {
+        "views.ReviewQueueResponse": {
             "type": "object",
             "properties": {
-                "collections": {
+                "collection_id": {
+                    "type": "string"
+                },
+                "due_count": {
+                    "description": "DueCount is the number of all due words, the queue holds up to limit of them",
+                    "type": "integer"
+                },
+                "words": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/views.WordCollectionResponse"
+                        "$ref": "#/definitions/views.UserWordDTO"
                     }
                 }
             }
         },
-        "views.GrammarRuleItem": {
+        "views.ReviewWordRequest": {
             "type": "object",
             "properties": {
-                "example": {
+                "grade": {
+                    "description": "Grade is the SM-2 recall grade: 0 - forgot completely, 3 - recalled with difficulty, 5 - perfect recall",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "views.ReviewWordResponse": {
+            "type": "object",
+            "properties": {
+                "word": {
+                    "$ref": "#/definitions/views.UserWordDTO"
+                }
+            }
+        },
+        "views.SessionAnswerDTO": {
+            "type": "object",
+            "properties": {
+                "audio_url": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "question_id": {
+                    "type": "integer"
+                },
+                "question_text": {
                     "type": "string"
                 },
-                "note": {
+                "transcript": {
                     "type": "string"
                 }
             }
         },
-        "views.Question": {
+        "views.SessionDetailDTO": {
             "type": "object",
             "properties": {
+                "analysis": {
+                    "$ref": "#/definitions/views.CompleteSessionResp"
+                },
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.SessionAnswerDTO"
+                    }
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
                 "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "topic_id": {
                     "type": "integer"
                 },
-                "text": {
+                "topic_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.SessionDetailResponse": {
+            "type": "object",
+            "properties": {
+                "session": {
+                    "$ref": "#/definitions/views.SessionDetailDTO"
+                }
+            }
+        },
+        "views.SessionSummaryDTO": {
+            "type": "object",
+            "properties": {
+                "answers_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
                     "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "overall_level": {
+                    "type": "string",
+                    "example": "B1"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                },
+                "topic_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "topic_title": {
+                    "type": "string",
+                    "example": "Travelling"
+                }
+            }
+        },
+        "views.SkillTrendDTO": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "number",
+                    "example": -0.8
+                },
+                "direction": {
+                    "type": "string",
+                    "enum": [
+                        "improving",
+                        "declining",
+                        "stable",
+                        "insufficient_data"
+                    ],
+                    "example": "improving"
+                },
+                "previous": {
+                    "type": "number",
+                    "example": 2
+                },
+                "recent": {
+                    "type": "number",
+                    "example": 1.2
+                },
+                "skill": {
+                    "type": "string",
+                    "enum": [
+                        "level",
+                        "grammar",
+                        "vocabulary",
+                        "fluency",
+                        "fillers"
+                    ],
+                    "example": "grammar"
                 }
             }
         },
@@ -1079,24 +4660,153 @@ const docTemplate = `{
                 }
             }
         },
+        "views.StreamMessage": {
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "error": {
+                    "$ref": "#/definitions/views.Error"
+                },
+                "transcript": {
+                    "type": "string",
+                    "example": "I usually spend my weekends"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "interim",
+                        "final",
+                        "saved",
+                        "error"
+                    ],
+                    "example": "interim"
+                }
+            }
+        },
         "views.SuccessResponse": {
             "type": "object",
             "properties": {
                 "data": {}
             }
         },
+        "views.TransferUserWordRequest": {
+            "type": "object",
+            "properties": {
+                "target_collection_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "views.UpdateUserSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "feedback_language": {
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "ru",
+                        "be"
+                    ],
+                    "example": "ru"
+                },
+                "time_zone": {
+                    "description": "TimeZone is an IANA time zone name",
+                    "type": "string",
+                    "example": "Europe/Minsk"
+                }
+            }
+        },
+        "views.UpdateUserWordRequest": {
+            "type": "object",
+            "properties": {
+                "example": {
+                    "type": "string",
+                    "example": "Our itinerary includes three cities."
+                },
+                "translation": {
+                    "type": "string",
+                    "example": "маршрут"
+                },
+                "word": {
+                    "type": "string",
+                    "example": "itinerary"
+                }
+            }
+        },
+        "views.UpdateWordCollectionResponse": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "$ref": "#/definitions/views.WordCollectionResponse"
+                }
+            }
+        },
+        "views.UserDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "views.UserSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "feedback_language": {
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "ru",
+                        "be"
+                    ],
+                    "example": "ru"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Europe/Minsk"
+                }
+            }
+        },
         "views.UserWordDTO": {
             "type": "object",
             "properties": {
+                "ease_factor": {
+                    "type": "number"
+                },
                 "example": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "interval_days": {
+                    "type": "integer"
+                },
+                "lapses": {
+                    "type": "integer"
+                },
+                "last_reviewed_at": {
+                    "type": "string"
+                },
+                "learned_at": {
+                    "type": "string"
+                },
                 "next_review_date": {
                     "type": "string"
                 },
+                "repetitions": {
+                    "type": "integer"
+                },
                 "review_count": {
                     "type": "integer"
                 },
@@ -1108,6 +4818,14 @@ const docTemplate = `{
                 }
             }
         },
+        "views.UserWordResponse": {
+            "type": "object",
+            "properties": {
+                "word": {
+                    "$ref": "#/definitions/views.UserWordDTO"
+                }
+            }
+        },
         "views.VocabularyWord": {
             "type": "object",
             "properties": {
@@ -1185,6 +4903,35 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "views.WordSuggestionsResponse": {
+            "type": "object",
+            "properties": {
+                "collection_id": {
+                    "type": "string"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.AISuggestionDTO"
+                    }
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "type": "apiKey",
+            "name": "X-Admin-Token",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
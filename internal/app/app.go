@@ -27,23 +27,84 @@ type QuestionsGetter interface {
 }
 
 type SessionsCreator interface {
-	StartSession(ctx context.Context, sessionID string, topicID int) (entity.Session, error)
+	StartSession(ctx context.Context, sessionID string, userID, topicID int) (entity.Session, error)
 }
 
-type SessionCompleter interface {
-	CompleteSession(ctx context.Context, sessionID string) (entity.AnalyzeTextResult, error)
+type SessionCompletionEnqueuer interface {
+	EnqueueCompletion(
+		ctx context.Context,
+		sessionID string,
+		userID int,
+		reanalyze bool,
+		prompt entity.PromptVersion,
+		feedbackLanguage string,
+	) (entity.Job, error)
+}
+
+type UserProgressGetter interface {
+	GetProgress(ctx context.Context, userID, limit int) (entity.UserProgress, error)
+}
+
+type GrammarMistakesGetter interface {
+	GetMistakes(ctx context.Context, userID, limit int) ([]entity.GrammarMistakeCategory, error)
+}
+
+type UserSettingsGetter interface {
+	GetSettings(ctx context.Context, userID int) (entity.UserSettings, error)
+}
+
+type UserSettingsUpdater interface {
+	UpdateSettings(ctx context.Context, userID int, update entity.UserSettingsUpdate) (entity.UserSettings, error)
+}
+
+type PromptPreviewer interface {
+	PreviewPrompt(ctx context.Context, preview entity.PromptPreview) (entity.PromptVersion, string, error)
+}
+
+type JobGetter interface {
+	GetJob(ctx context.Context, jobID string, userID int) (entity.Job, error)
+}
+
+type UserSessionsGetter interface {
+	GetSessions(ctx context.Context, userID, limit, offset int) ([]entity.SessionSummary, error)
+}
+
+type SessionDetailGetter interface {
+	GetSessionDetail(ctx context.Context, sessionID string, userID int) (entity.SessionDetail, error)
 }
 
 type AnswerAttacher interface {
 	AttachAnswerToSession(
 		ctx context.Context,
 		sessionID string,
+		userID int,
 		questionID int,
 		file *multipart.File,
 		header *multipart.FileHeader,
 	) error
 }
 
+type AnswerStreamer interface {
+	StreamAnswer(
+		ctx context.Context,
+		sessionID string,
+		userID int,
+		questionID int,
+		recv func() ([]byte, error),
+		send func(update entity.TranscriptUpdate) error,
+	) (int, error)
+}
+
+type ReadAloudAssessor interface {
+	AssessReadAloud(
+		ctx context.Context,
+		userID int,
+		source entity.ReadAloudSource,
+		file *multipart.File,
+		header *multipart.FileHeader,
+	) (entity.ReadAloudResult, error)
+}
+
 type ArticlesGetter interface {
 	GetArticles(ctx context.Context, limit, offset int) ([]entity.ArticlePreview, error)
 }
@@ -72,6 +133,59 @@ type WordAdder interface {
 	AddWord(ctx context.Context, collectionID, word, translation string, example *string, userID int) (entity.UserWord, error)
 }
 
+type ReviewQueueGetter interface {
+	GetReviewQueue(ctx context.Context, collectionID string, userID, limit int) (entity.ReviewQueue, error)
+}
+
+type WordReviewer interface {
+	ReviewWord(ctx context.Context, wordID string, userID, grade int) (entity.UserWord, error)
+}
+
+type WordSuggestionsGetter interface {
+	GetSuggestions(ctx context.Context, collectionID string, userID int, refresh bool) (entity.WordSuggestions, error)
+}
+
+type WordSuggestionAccepter interface {
+	AcceptSuggestion(ctx context.Context, collectionID string, userID int, word string) (entity.UserWord, error)
+}
+
+type UserWordUpdater interface {
+	UpdateWord(ctx context.Context, collectionID, wordID string, userID int, update entity.UserWordUpdate) (entity.UserWord, error)
+}
+
+type UserWordsDeleter interface {
+	DeleteWord(ctx context.Context, collectionID, wordID string, userID int) error
+	DeleteWords(ctx context.Context, collectionID string, userID int, wordIDs []string) (int, error)
+}
+
+type UserWordTransferrer interface {
+	TransferWord(ctx context.Context, collectionID, wordID, targetID string, userID int, asCopy bool) (entity.UserWord, error)
+}
+
+type WordCollectionUpdater interface {
+	UpdateCollection(
+		ctx context.Context,
+		collectionID string,
+		userID int,
+		name *string,
+		imageFile *multipart.File,
+		imageHeader *multipart.FileHeader,
+		removeImage bool,
+	) (entity.WordCollection, error)
+}
+
+type UserRegistrar interface {
+	Register(ctx context.Context, email, password string) (entity.AuthToken, error)
+}
+
+type UserAuthenticator interface {
+	Login(ctx context.Context, email, password string) (entity.AuthToken, error)
+}
+
+type TokenParser interface {
+	ParseToken(token string) (int, error)
+}
+
 type App struct {
 	server *http.Server
 	mux    *http.ServeMux
@@ -80,7 +194,9 @@ type App struct {
 	questionsGetter       QuestionsGetter
 	sessionsCreator       SessionsCreator
 	answerAttacher        AnswerAttacher
-	sessionCompleter      SessionCompleter
+	answerStreamer        AnswerStreamer
+	readAloudAssessor     ReadAloudAssessor
+	completionEnqueuer    SessionCompletionEnqueuer
 	getArticlesUC         ArticlesGetter
 	getArticleByIDUC      ArticleByIDGetter
 	createCollectionUC    WordCollectionCreator
@@ -88,6 +204,25 @@ type App struct {
 	getUserCollectionsUC  UserCollectionsGetter
 	getCollectionDetailUC CollectionDetailGetter
 	addWordToCollectionUC WordAdder
+	registerUC            UserRegistrar
+	loginUC               UserAuthenticator
+	tokenParser           TokenParser
+	userSessionsGetter    UserSessionsGetter
+	sessionDetailGetter   SessionDetailGetter
+	jobGetter             JobGetter
+	promptPreviewer       PromptPreviewer
+	userSettingsGetter    UserSettingsGetter
+	userSettingsUpdater   UserSettingsUpdater
+	userProgressGetter    UserProgressGetter
+	grammarMistakesGetter GrammarMistakesGetter
+	reviewQueueGetter     ReviewQueueGetter
+	wordReviewer          WordReviewer
+	suggestionsGetter     WordSuggestionsGetter
+	suggestionAccepter    WordSuggestionAccepter
+	wordUpdater           UserWordUpdater
+	wordsDeleter          UserWordsDeleter
+	wordTransferrer       UserWordTransferrer
+	collectionUpdater     WordCollectionUpdater
 
 	cfg    *config.Config
 	logger *zap.Logger
@@ -98,7 +233,9 @@ func New(
 	questionsGetter QuestionsGetter,
 	sessionsCreator SessionsCreator,
 	answerAttacher AnswerAttacher,
-	completer SessionCompleter,
+	answerStreamer AnswerStreamer,
+	readAloudAssessor ReadAloudAssessor,
+	completionEnqueuer SessionCompletionEnqueuer,
 	getArticlesUC ArticlesGetter,
 	getArticleByIDUC ArticleByIDGetter,
 	createCollectionUC WordCollectionCreator,
@@ -106,6 +243,25 @@ func New(
 	getUserCollectionsUC UserCollectionsGetter,
 	getCollectionDetailUC CollectionDetailGetter,
 	addWordToCollectionUC WordAdder,
+	registerUC UserRegistrar,
+	loginUC UserAuthenticator,
+	tokenParser TokenParser,
+	userSessionsGetter UserSessionsGetter,
+	sessionDetailGetter SessionDetailGetter,
+	jobGetter JobGetter,
+	promptPreviewer PromptPreviewer,
+	userSettingsGetter UserSettingsGetter,
+	userSettingsUpdater UserSettingsUpdater,
+	userProgressGetter UserProgressGetter,
+	grammarMistakesGetter GrammarMistakesGetter,
+	reviewQueueGetter ReviewQueueGetter,
+	wordReviewer WordReviewer,
+	suggestionsGetter WordSuggestionsGetter,
+	suggestionAccepter WordSuggestionAccepter,
+	wordUpdater UserWordUpdater,
+	wordsDeleter UserWordsDeleter,
+	wordTransferrer UserWordTransferrer,
+	collectionUpdater WordCollectionUpdater,
 	cfg *config.Config,
 	logger *zap.Logger,
 ) App {
@@ -124,7 +280,9 @@ func New(
 		questionsGetter:       questionsGetter,
 		sessionsCreator:       sessionsCreator,
 		answerAttacher:        answerAttacher,
-		sessionCompleter:      completer,
+		answerStreamer:        answerStreamer,
+		readAloudAssessor:     readAloudAssessor,
+		completionEnqueuer:    completionEnqueuer,
 		getArticlesUC:         getArticlesUC,
 		getArticleByIDUC:      getArticleByIDUC,
 		createCollectionUC:    createCollectionUC,
@@ -132,6 +290,25 @@ func New(
 		getUserCollectionsUC:  getUserCollectionsUC,
 		getCollectionDetailUC: getCollectionDetailUC,
 		addWordToCollectionUC: addWordToCollectionUC,
+		registerUC:            registerUC,
+		loginUC:               loginUC,
+		tokenParser:           tokenParser,
+		userSessionsGetter:    userSessionsGetter,
+		sessionDetailGetter:   sessionDetailGetter,
+		jobGetter:             jobGetter,
+		promptPreviewer:       promptPreviewer,
+		userSettingsGetter:    userSettingsGetter,
+		userSettingsUpdater:   userSettingsUpdater,
+		userProgressGetter:    userProgressGetter,
+		grammarMistakesGetter: grammarMistakesGetter,
+		reviewQueueGetter:     reviewQueueGetter,
+		wordReviewer:          wordReviewer,
+		suggestionsGetter:     suggestionsGetter,
+		suggestionAccepter:    suggestionAccepter,
+		wordUpdater:           wordUpdater,
+		wordsDeleter:          wordsDeleter,
+		wordTransferrer:       wordTransferrer,
+		collectionUpdater:     collectionUpdater,
 		cfg:                   cfg,
 		logger:                logger,
 	}
@@ -173,19 +350,48 @@ func (s *App) InitREST() {
 		httpSwagger.URL("/swagger/doc.json"),
 	))
 
+	s.mux.HandleFunc("POST /auth/register", s.register())
+	s.mux.HandleFunc("POST /auth/login", s.login())
+
+	s.mux.HandleFunc("GET /me/settings", s.authorized(s.getUserSettings()))
+	s.mux.HandleFunc("PATCH /me/settings", s.authorized(s.updateUserSettings()))
+	s.mux.HandleFunc("GET /me/progress", s.authorized(s.getUserProgress()))
+	s.mux.HandleFunc("GET /me/grammar-mistakes", s.authorized(s.getGrammarMistakes()))
+
 	s.mux.HandleFunc("GET /topics", s.getAllTopics())
 	s.mux.HandleFunc("GET /topics/{topicID}/questions", s.getTopicQuestions())
 
-	s.mux.HandleFunc("POST /sessions", s.startSession())
-	s.mux.HandleFunc("POST /sessions/{sessionID}/answer", s.attachAnswerToSession())
-	s.mux.HandleFunc("POST /sessions/{sessionID}/complete", s.completeSession())
+	s.mux.HandleFunc("GET /sessions", s.authorized(s.getUserSessions()))
+	s.mux.HandleFunc("GET /sessions/{sessionID}", s.authorized(s.getSessionDetail()))
+	s.mux.HandleFunc("POST /sessions", s.authorized(s.startSession()))
+	s.mux.HandleFunc("POST /sessions/{sessionID}/answer", s.authorized(s.attachAnswerToSession()))
+	s.mux.HandleFunc("GET /sessions/{sessionID}/answer/stream", s.authorized(s.streamAnswer()))
+	s.mux.HandleFunc("POST /sessions/{sessionID}/complete", s.authorized(s.completeSession()))
+
+	s.mux.HandleFunc("GET /jobs/{jobID}", s.authorized(s.getJob()))
+
+	s.mux.HandleFunc("POST /read-aloud", s.authorized(s.assessReadAloud()))
+
+	s.mux.HandleFunc("POST /admin/prompts/preview", s.admin(s.previewPrompt()))
 
 	s.mux.HandleFunc("GET /articles", s.getArticles())
 	s.mux.HandleFunc("GET /articles/{id}", s.getArticleByID())
 
-	s.mux.HandleFunc("GET /collections", s.getUserCollections())
-	s.mux.HandleFunc("GET /collections/{id}", s.getCollectionDetail())
-	s.mux.HandleFunc("POST /collections", s.createWordCollection())
-	s.mux.HandleFunc("DELETE /collections/{id}", s.deleteWordCollection())
-	s.mux.HandleFunc("POST /collections/{id}/words", s.addWordToCollection())
+	s.mux.HandleFunc("GET /collections", s.authorized(s.getUserCollections()))
+	s.mux.HandleFunc("GET /collections/{id}", s.authorized(s.getCollectionDetail()))
+	s.mux.HandleFunc("POST /collections", s.authorized(s.createWordCollection()))
+	s.mux.HandleFunc("PATCH /collections/{id}", s.authorized(s.updateWordCollection()))
+	s.mux.HandleFunc("DELETE /collections/{id}", s.authorized(s.deleteWordCollection()))
+	s.mux.HandleFunc("POST /collections/{id}/words", s.authorized(s.addWordToCollection()))
+	s.mux.HandleFunc("PATCH /collections/{id}/words/{wordID}", s.authorized(s.updateUserWord()))
+	s.mux.HandleFunc("DELETE /collections/{id}/words/{wordID}", s.authorized(s.deleteUserWord()))
+	s.mux.HandleFunc("POST /collections/{id}/words/bulk-delete", s.authorized(s.deleteUserWords()))
+	s.mux.HandleFunc("POST /collections/{id}/words/{wordID}/move", s.authorized(s.moveUserWord()))
+	s.mux.HandleFunc("POST /collections/{id}/words/{wordID}/copy", s.authorized(s.copyUserWord()))
+	s.mux.HandleFunc("GET /collections/{id}/review", s.authorized(s.getReviewQueue()))
+	s.mux.HandleFunc("GET /collections/{id}/suggestions", s.authorized(s.getWordSuggestions()))
+	s.mux.HandleFunc("POST /collections/{id}/suggestions/refresh", s.authorized(s.refreshWordSuggestions()))
+	s.mux.HandleFunc("POST /collections/{id}/suggestions/accept", s.authorized(s.acceptWordSuggestion()))
+
+	s.mux.HandleFunc("POST /words/{wordID}/review", s.authorized(s.reviewWord()))
 }
@@ -2,15 +2,19 @@ package app
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"speech-processing-service/internal/app/views"
+	"speech-processing-service/internal/entity"
 	"speech-processing-service/internal/errs"
 
 	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
 
 	"github.com/google/uuid"
 )
@@ -18,11 +22,102 @@ import (
 const (
 	topicIDKey   = "topicID"
 	sessionIDKey = "sessionID"
+	jobIDKey     = "jobID"
 
 	answerKey     = "answer"
 	questionIDKey = "questionID"
+
+	reanalyzeKey     = "reanalyze"
+	promptKey        = "prompt"
+	promptVersionKey = "prompt_version"
+	feedbackLangKey  = "feedback_language"
+
+	audioKey     = "audio"
+	textKey      = "text"
+	wordIDKey    = "word_id"
+	articleIDKey = "article_id"
+	sentenceKey  = "sentence"
+
+	// multipartMemory is how much of a multipart form is kept in memory, the rest is spooled to disk
+	multipartMemory = 10 << 20
+	// multipartOverhead leaves room for the form fields and part headers next to the answer file
+	multipartOverhead = 1 << 20
+	// maxImageSize is the size limit of a collection cover image
+	maxImageSize = 10 << 20
 )
 
+// register godoc
+// @Summary Register user
+// @Description Create a new user account and return a bearer token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body views.AuthRequest true "Credentials"
+// @Success 200 {object} views.SuccessResponse{data=views.AuthResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 409 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Router /auth/register [post]
+func (s *App) register() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req views.AuthRequest
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&req); err != nil {
+			s.logger.Error("handlers.register", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+			return
+		}
+
+		token, err := s.registerUC.Register(r.Context(), req.Email, req.Password)
+		if err != nil {
+			s.logger.Error("handlers.register", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewAuthResponse(token), nil)
+	}
+}
+
+// login godoc
+// @Summary Login
+// @Description Exchange email and password for a bearer token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body views.AuthRequest true "Credentials"
+// @Success 200 {object} views.SuccessResponse{data=views.AuthResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Router /auth/login [post]
+func (s *App) login() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req views.AuthRequest
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&req); err != nil {
+			s.logger.Error("handlers.login", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+			return
+		}
+
+		token, err := s.loginUC.Login(r.Context(), req.Email, req.Password)
+		if err != nil {
+			s.logger.Error("handlers.login", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewAuthResponse(token), nil)
+	}
+}
+
 // getAllTopics godoc
 // @Summary Get all topics
 // @Description Get a list of all topics
@@ -84,6 +179,8 @@ func (s *App) getTopicQuestions() func(http.ResponseWriter, *http.Request) {
 // @Failure 400 {object} views.ErrorResponse{error=views.Error}
 // @Failure 404 {object} views.ErrorResponse{error=views.Error}
 // @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
 // @Router /sessions [post]
 func (s *App) startSession() func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -101,7 +198,8 @@ func (s *App) startSession() func(http.ResponseWriter, *http.Request) {
 		}
 
 		sessionID := uuid.New().String()
-		session, err := s.sessionsCreator.StartSession(r.Context(), sessionID, req.TopicID)
+		userID := userIDFromContext(r.Context())
+		session, err := s.sessionsCreator.StartSession(r.Context(), sessionID, userID, req.TopicID)
 		if err != nil {
 			s.logger.Error("handlers.startSession", zap.Error(err))
 
@@ -116,7 +214,9 @@ func (s *App) startSession() func(http.ResponseWriter, *http.Request) {
 
 // attachAnswerToSession godoc
 // @Summary Attach answer to session
-// @Description Attach an answer to a session
+// @Description Attach an answer to a session. Accepted formats: wav, mp3, aac, m4a, ogg, flac, webm;
+// @Description the format is detected from the file content, size and duration are limited
+// @Description The question must belong to the session topic; a repeated upload replaces the previous answer
 // @Tags session
 // @Accept multipart/form-data
 // @Produce json
@@ -127,6 +227,9 @@ func (s *App) startSession() func(http.ResponseWriter, *http.Request) {
 // @Failure 400 {object} views.ErrorResponse{error=views.Error}
 // @Failure 404 {object} views.ErrorResponse{error=views.Error}
 // @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 409 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
 // @Router /session/{sessionID}/answer [post]
 func (s *App) attachAnswerToSession() func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -137,6 +240,12 @@ func (s *App) attachAnswerToSession() func(http.ResponseWriter, *http.Request) {
 			return
 		}
 
+		if err := s.parseAnswerForm(w, r); err != nil {
+			s.logger.Error("handlers.attachAnswerToSession", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
 		questionIDString := r.FormValue(questionIDKey)
 		questionID, err := strconv.Atoi(questionIDString)
 		if err != nil {
@@ -157,7 +266,8 @@ func (s *App) attachAnswerToSession() func(http.ResponseWriter, *http.Request) {
 		}
 		defer file.Close()
 
-		err = s.answerAttacher.AttachAnswerToSession(r.Context(), sessionID, questionID, &file, header)
+		userID := userIDFromContext(r.Context())
+		err = s.answerAttacher.AttachAnswerToSession(r.Context(), sessionID, userID, questionID, &file, header)
 		if err != nil {
 			s.logger.Error("handlers.attachAnswerToSession", zap.Error(err))
 
@@ -170,14 +280,210 @@ func (s *App) attachAnswerToSession() func(http.ResponseWriter, *http.Request) {
 	}
 }
 
+// parseAnswerForm parses a multipart form carrying an answer recording. The body is capped at the answer size
+// limit, so an oversized upload is rejected while it is read instead of being spooled to disk first.
+func (s *App) parseAnswerForm(w http.ResponseWriter, r *http.Request) error {
+	r.Body = http.MaxBytesReader(w, r.Body, s.cfg.Answers.MaxSize+multipartOverhead)
+
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return errs.New(errs.ErrInvalidAudio, fmt.Sprintf("file is larger than %d bytes", s.cfg.Answers.MaxSize))
+		}
+
+		return errs.New(errs.ErrDecodingJSON, "invalid multipart form: "+err.Error())
+	}
+
+	return nil
+}
+
+// parseImageForm parses a multipart form carrying a collection cover image. The body is capped at the image
+// size limit, so an oversized upload is rejected while it is read instead of being spooled to disk first.
+func (s *App) parseImageForm(w http.ResponseWriter, r *http.Request) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImageSize+multipartOverhead)
+
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return errs.New(errs.ErrInvalidArgument, fmt.Sprintf("image is larger than %d bytes", maxImageSize))
+		}
+
+		return errs.New(errs.ErrDecodingJSON, "invalid multipart form: "+err.Error())
+	}
+
+	return nil
+}
+
+// streamAnswer godoc
+// @Summary Stream an answer
+// @Description WebSocket endpoint for live practice. Send the recording in binary messages and {"type":"finish"}
+// @Description when done; the server replies with interim and final transcripts and, once the answer is stored,
+// @Description a "saved" message with the answer ID. Disconnecting, or sending nothing for longer than the idle
+// @Description timeout, also stores what was recorded; recordings over the size or duration limit are rejected.
+// @Description Browsers may pass the access token in the token query parameter
+// @Tags session
+// @Produce json
+// @Param sessionID path string true "Session ID"
+// @Param questionID query int true "Question ID"
+// @Param token query string false "Access token, if the Authorization header can't be set"
+// @Success 101 {object} views.StreamMessage
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /sessions/{sessionID}/answer/stream [get]
+func (s *App) streamAnswer() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.PathValue(sessionIDKey)
+		if err := uuid.Validate(sessionID); err != nil {
+			s.logger.Error("handlers.streamAnswer", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("sessionID: %s", sessionID)))
+			return
+		}
+
+		questionIDString := r.URL.Query().Get(questionIDKey)
+		questionID, err := strconv.Atoi(questionIDString)
+		if err != nil {
+			s.logger.Error("handlers.streamAnswer", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeNumeric, fmt.Sprintf("questionID: %s", questionIDString)))
+			return
+		}
+
+		userID := userIDFromContext(r.Context())
+
+		server := websocket.Server{
+			Handler: func(ws *websocket.Conn) {
+				defer ws.Close()
+
+				ws.MaxPayloadBytes = maxAudioFrame
+
+				answerID, err := s.answerStreamer.StreamAnswer(
+					r.Context(),
+					sessionID,
+					userID,
+					questionID,
+					func() ([]byte, error) {
+						return s.receiveAudio(ws)
+					},
+					func(update entity.TranscriptUpdate) error {
+						return websocket.JSON.Send(ws, views.NewStreamUpdate(update))
+					},
+				)
+				if err != nil {
+					s.logger.Error("handlers.streamAnswer", zap.Error(err))
+
+					if err := websocket.JSON.Send(ws, views.NewStreamError(err)); err != nil {
+						s.logger.Warn("handlers.streamAnswer", zap.Error(err))
+					}
+
+					return
+				}
+
+				if err := websocket.JSON.Send(ws, views.NewStreamSaved(answerID)); err != nil {
+					s.logger.Warn("handlers.streamAnswer", zap.Error(err))
+				}
+			},
+		}
+
+		server.ServeHTTP(w, r)
+	}
+}
+
+// assessReadAloud godoc
+// @Summary Assess reading aloud
+// @Description Compares the recording of a text read aloud with the text and reports omitted, inserted and
+// @Description mispronounced words with per-word accuracy. The text is given directly, as the example of a
+// @Description collection word or as a sentence of an article; exactly one source is required
+// @Tags read-aloud
+// @Accept multipart/form-data
+// @Produce json
+// @Param audio formData file true "Recording"
+// @Param text formData string false "Reference text"
+// @Param word_id formData string false "Collection word whose example is read"
+// @Param article_id formData int false "Article whose sentence is read"
+// @Param sentence formData int false "Zero-based sentence index in the article" default(0)
+// @Success 200 {object} views.SuccessResponse{data=views.ReadAloudResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /read-aloud [post]
+func (s *App) assessReadAloud() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := s.parseAnswerForm(w, r); err != nil {
+			s.logger.Error("handlers.assessReadAloud", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		file, header, err := r.FormFile(audioKey)
+		if err != nil {
+			s.logger.Error("handlers.assessReadAloud", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+			return
+		}
+		defer file.Close()
+
+		source := entity.ReadAloudSource{
+			Text:   r.FormValue(textKey),
+			WordID: r.FormValue(wordIDKey),
+		}
+
+		if source.WordID != "" {
+			if err := uuid.Validate(source.WordID); err != nil {
+				views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("word_id: %s", source.WordID)))
+				return
+			}
+		}
+
+		if articleID := r.FormValue(articleIDKey); articleID != "" {
+			source.ArticleID, err = strconv.Atoi(articleID)
+			if err != nil {
+				views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeNumeric, fmt.Sprintf("article_id: %s", articleID)))
+				return
+			}
+		}
+
+		if sentence := r.FormValue(sentenceKey); sentence != "" {
+			source.Sentence, err = strconv.Atoi(sentence)
+			if err != nil {
+				views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeNumeric, fmt.Sprintf("sentence: %s", sentence)))
+				return
+			}
+		}
+
+		userID := userIDFromContext(r.Context())
+		result, err := s.readAloudAssessor.AssessReadAloud(r.Context(), userID, source, &file, header)
+		if err != nil {
+			s.logger.Error("handlers.assessReadAloud", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewReadAloudResponse(result), nil)
+	}
+}
+
 // completeSession godoc
 // @Summary Complete session
-// @Description Complete a session
+// @Description Schedule session completion and return the job to poll via GET /jobs/{jobID}. A completed session
+// @Description resolves to the stored analysis; reanalyze=true or another prompt regenerates it from the stored
+// @Description transcripts. By default the latest version of the session_analysis prompt is used and the feedback
+// @Description is written in the language from the user settings. While a completion is queued or running it is
+// @Description returned again, or 409 is returned if it uses another prompt or language
 // @Tags session
 // @Produce json
 // @Param sessionID path string true "Session ID"
-// @Success 200 {object} views.SuccessResponse{data=views.CompleteSessionResp}
+// @Param reanalyze query bool false "Regenerate the analysis" default(false)
+// @Param prompt query string false "Prompt name" default(session_analysis)
+// @Param prompt_version query string false "Prompt version, e.g. v2; the latest by default"
+// @Param feedback_language query string false "Language of explanations and feedback; the user setting by default" Enums(en, ru, be)
+// @Success 202 {object} views.SuccessResponse{data=views.JobResponse}
 // @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 409 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
 // @Router /session/{sessionID}/complete [post]
 func (s *App) completeSession() func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -188,7 +494,26 @@ func (s *App) completeSession() func(http.ResponseWriter, *http.Request) {
 			return
 		}
 
-		result, err := s.sessionCompleter.CompleteSession(r.Context(), sessionID)
+		reanalyze := false
+		if reanalyzeParam := r.URL.Query().Get(reanalyzeKey); reanalyzeParam != "" {
+			parsed, err := strconv.ParseBool(reanalyzeParam)
+			if err != nil {
+				s.logger.Error("handlers.completeSession", zap.Error(err))
+				views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, fmt.Sprintf("reanalyze: %s", reanalyzeParam)))
+				return
+			}
+			reanalyze = parsed
+		}
+
+		prompt := entity.PromptVersion{
+			Name:    r.URL.Query().Get(promptKey),
+			Version: r.URL.Query().Get(promptVersionKey),
+		}
+
+		userID := userIDFromContext(r.Context())
+		feedbackLanguage := r.URL.Query().Get(feedbackLangKey)
+
+		job, err := s.completionEnqueuer.EnqueueCompletion(r.Context(), sessionID, userID, reanalyze, prompt, feedbackLanguage)
 		if err != nil {
 			s.logger.Error("handlers.completeSession", zap.Error(err))
 
@@ -197,7 +522,116 @@ func (s *App) completeSession() func(http.ResponseWriter, *http.Request) {
 			return
 		}
 
-		views.Return(s.logger, w, r, views.NewCompleteSessionResp(&result), nil)
+		views.ReturnWithStatus(s.logger, w, r, http.StatusAccepted, views.NewJobResponse(job), nil)
+	}
+}
+
+// getUserSessions godoc
+// @Summary Get session history
+// @Description Get the authenticated user's speaking sessions, newest first
+// @Tags session
+// @Produce json
+// @Param limit query int false "Number of sessions to return" default(20)
+// @Param offset query int false "Number of sessions to skip" default(0)
+// @Success 200 {object} views.SuccessResponse{data=views.GetUserSessionsResponse}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /sessions [get]
+func (s *App) getUserSessions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 20
+		offset := 0
+
+		if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+			if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+				limit = parsedLimit
+			}
+		}
+
+		if offsetParam := r.URL.Query().Get("offset"); offsetParam != "" {
+			if parsedOffset, err := strconv.Atoi(offsetParam); err == nil && parsedOffset >= 0 {
+				offset = parsedOffset
+			}
+		}
+
+		userID := userIDFromContext(r.Context())
+		sessions, err := s.userSessionsGetter.GetSessions(r.Context(), userID, limit, offset)
+		if err != nil {
+			s.logger.Error("handlers.getUserSessions", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewGetUserSessionsResponse(sessions), nil)
+	}
+}
+
+// getSessionDetail godoc
+// @Summary Get session
+// @Description Get a session with its answers, audio URLs and stored analysis
+// @Tags session
+// @Produce json
+// @Param sessionID path string true "Session ID"
+// @Success 200 {object} views.SuccessResponse{data=views.SessionDetailResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /sessions/{sessionID} [get]
+func (s *App) getSessionDetail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.PathValue(sessionIDKey)
+		if err := uuid.Validate(sessionID); err != nil {
+			s.logger.Error("handlers.getSessionDetail", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("sessionID: %s", sessionID)))
+			return
+		}
+
+		userID := userIDFromContext(r.Context())
+		detail, err := s.sessionDetailGetter.GetSessionDetail(r.Context(), sessionID, userID)
+		if err != nil {
+			s.logger.Error("handlers.getSessionDetail", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewSessionDetailResponse(detail), nil)
+	}
+}
+
+// getJob godoc
+// @Summary Get job
+// @Description Get background job status, progress and result
+// @Tags jobs
+// @Produce json
+// @Param jobID path string true "Job ID"
+// @Success 200 {object} views.SuccessResponse{data=views.JobResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /jobs/{jobID} [get]
+func (s *App) getJob() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID := r.PathValue(jobIDKey)
+		if err := uuid.Validate(jobID); err != nil {
+			s.logger.Error("handlers.getJob", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("jobID: %s", jobID)))
+			return
+		}
+
+		userID := userIDFromContext(r.Context())
+		job, err := s.jobGetter.GetJob(r.Context(), jobID, userID)
+		if err != nil {
+			s.logger.Error("handlers.getJob", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewJobResponse(job), nil)
 	}
 }
 
@@ -284,21 +718,21 @@ func (s *App) getArticleByID() http.HandlerFunc {
 // @Accept multipart/form-data
 // @Produce json
 // @Param name formData string true "Collection name"
-// @Param image formData file false "Collection image"
+// @Param image formData file false "Collection image: JPEG, PNG, GIF or WebP up to 10 MB"
 // @Success 200 {object} views.CreateWordCollectionResponse
 // @Failure 400 {object} views.ErrorResponse
 // @Failure 500 {object} views.ErrorResponse
+// @Failure 401 {object} views.ErrorResponse
+// @Security BearerAuth
 // @Router /collections [post]
 func (s *App) createWordCollection() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// TODO: Get userID from auth context
-		// For now, using hardcoded userID = 1
-		userID := 1
+		userID := userIDFromContext(r.Context())
 
 		// Парсинг multipart form
-		if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB max
+		if err := s.parseImageForm(w, r); err != nil {
 			s.logger.Error("handlers.createWordCollection: parse multipart form", zap.Error(err))
-			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, "invalid multipart form: "+err.Error()))
+			views.Return(s.logger, w, r, nil, err)
 			return
 		}
 
@@ -332,20 +766,121 @@ func (s *App) createWordCollection() http.HandlerFunc {
 	}
 }
 
+// updateWordCollection godoc
+// @Summary Update word collection
+// @Description Rename the collection and replace or remove its cover image. Accepts multipart/form-data with
+// @Description optional name, image and remove_image fields, or JSON views.UpdateWordCollectionRequest without an image.
+// @Description The previous image is deleted from storage
+// @Tags collections
+// @Accept multipart/form-data
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param name formData string false "New collection name"
+// @Param image formData file false "New cover image: JPEG, PNG, GIF or WebP up to 10 MB"
+// @Param remove_image formData bool false "Remove the cover image"
+// @Success 200 {object} views.SuccessResponse{data=views.UpdateWordCollectionResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /collections/{id} [patch]
+func (s *App) updateWordCollection() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromContext(r.Context())
+
+		collectionID := r.PathValue("id")
+		if err := uuid.Validate(collectionID); err != nil {
+			s.logger.Error("handlers.updateWordCollection: invalid uuid", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("collection id: %s", collectionID)))
+			return
+		}
+
+		var (
+			name        *string
+			removeImage bool
+			imageFile   *multipart.File
+			imageHeader *multipart.FileHeader
+		)
+
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			if err := s.parseImageForm(w, r); err != nil {
+				s.logger.Error("handlers.updateWordCollection: parse multipart form", zap.Error(err))
+				views.Return(s.logger, w, r, nil, err)
+				return
+			}
+
+			// Отсутствующее поле name оставляет имя без изменений, пустое отклоняется в usecase
+			if values, ok := r.MultipartForm.Value["name"]; ok && len(values) > 0 {
+				name = &values[0]
+			}
+
+			if value := r.FormValue("remove_image"); value != "" {
+				parsed, err := strconv.ParseBool(value)
+				if err != nil {
+					s.logger.Error("handlers.updateWordCollection: invalid remove_image", zap.Error(err))
+					views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, "remove_image must be a boolean"))
+					return
+				}
+				removeImage = parsed
+			}
+
+			file, header, err := r.FormFile("image")
+			if err == nil {
+				defer file.Close()
+				imageFile = &file
+				imageHeader = header
+			}
+		} else {
+			var req views.UpdateWordCollectionRequest
+
+			decoder := json.NewDecoder(r.Body)
+			decoder.DisallowUnknownFields()
+
+			if err := decoder.Decode(&req); err != nil {
+				s.logger.Error("handlers.updateWordCollection: failed to decode request", zap.Error(err))
+				views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+				return
+			}
+
+			name, removeImage = req.Name, req.RemoveImage
+		}
+
+		collection, err := s.collectionUpdater.UpdateCollection(
+			r.Context(),
+			collectionID,
+			userID,
+			name,
+			imageFile,
+			imageHeader,
+			removeImage,
+		)
+		if err != nil {
+			s.logger.Error("handlers.updateWordCollection", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewUpdateWordCollectionResponse(collection), nil)
+	}
+}
+
 // @Summary Delete word collection
-// @Description Delete a word collection by ID
+// @Description Delete a word collection by ID together with its cover image
 // @Tags collections
 // @Produce json
-// @Param id path int true "Collection ID"
+// @Param id path string true "Collection ID (UUID)"
 // @Success 200 {object} views.SuccessResponse
 // @Failure 400 {object} views.ErrorResponse
 // @Failure 404 {object} views.ErrorResponse
 // @Failure 500 {object} views.ErrorResponse
+// @Failure 401 {object} views.ErrorResponse
+// @Security BearerAuth
 // @Router /collections/{id} [delete]
 func (s *App) deleteWordCollection() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// TODO: Get userID from auth context
-		userID := 1
+		userID := userIDFromContext(r.Context())
 
 		// Получение ID коллекции (UUID)
 		collectionID := r.PathValue("id")
@@ -379,11 +914,12 @@ func (s *App) deleteWordCollection() http.HandlerFunc {
 // @Produce json
 // @Success 200 {object} views.GetUserCollectionsResponse
 // @Failure 500 {object} views.ErrorResponse
+// @Failure 401 {object} views.ErrorResponse
+// @Security BearerAuth
 // @Router /collections [get]
 func (s *App) getUserCollections() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// TODO: Get userID from auth context
-		userID := 1
+		userID := userIDFromContext(r.Context())
 
 		collections, err := s.getUserCollectionsUC.GetCollections(r.Context(), userID)
 		if err != nil {
@@ -405,11 +941,12 @@ func (s *App) getUserCollections() http.HandlerFunc {
 // @Failure 400 {object} views.ErrorResponse
 // @Failure 404 {object} views.ErrorResponse
 // @Failure 500 {object} views.ErrorResponse
+// @Failure 401 {object} views.ErrorResponse
+// @Security BearerAuth
 // @Router /collections/{id} [get]
 func (s *App) getCollectionDetail() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// TODO: Get userID from auth context
-		userID := 1
+		userID := userIDFromContext(r.Context())
 
 		// Получение ID коллекции (UUID)
 		collectionID := r.PathValue("id")
@@ -449,11 +986,12 @@ func (s *App) getCollectionDetail() http.HandlerFunc {
 // @Failure 400 {object} views.ErrorResponse
 // @Failure 404 {object} views.ErrorResponse
 // @Failure 500 {object} views.ErrorResponse
+// @Failure 401 {object} views.ErrorResponse
+// @Security BearerAuth
 // @Router /collections/{id}/words [post]
 func (s *App) addWordToCollection() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// TODO: Get userID from auth context
-		userID := 1
+		userID := userIDFromContext(r.Context())
 
 		// Получение ID коллекции (UUID)
 		collectionID := r.PathValue("id")
@@ -496,3 +1034,646 @@ func (s *App) addWordToCollection() http.HandlerFunc {
 		views.Return(s.logger, w, r, views.NewAddWordToCollectionResponse(word), nil)
 	}
 }
+
+// updateUserWord godoc
+// @Summary Update word
+// @Description Fix the word, translation or example of a word in the collection; missing fields keep their values
+// @Description and an empty example removes it. The review schedule of the word is kept
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param wordID path string true "Word ID (UUID)"
+// @Param request body views.UpdateUserWordRequest true "Fields to change"
+// @Success 200 {object} views.SuccessResponse{data=views.UserWordResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /collections/{id}/words/{wordID} [patch]
+func (s *App) updateUserWord() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromContext(r.Context())
+
+		collectionID, wordID := r.PathValue("id"), r.PathValue("wordID")
+		if err := validateWordPath(collectionID, wordID); err != nil {
+			s.logger.Error("handlers.updateUserWord", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		var req views.UpdateUserWordRequest
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&req); err != nil {
+			s.logger.Error("handlers.updateUserWord: failed to decode request", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+			return
+		}
+
+		word, err := s.wordUpdater.UpdateWord(r.Context(), collectionID, wordID, userID, entity.UserWordUpdate{
+			Word:        req.Word,
+			Translation: req.Translation,
+			Example:     req.Example,
+		})
+		if err != nil {
+			s.logger.Error("handlers.updateUserWord", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewUserWordResponse(word), nil)
+	}
+}
+
+// deleteUserWord godoc
+// @Summary Delete word
+// @Description Delete a word from the collection together with its review history
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param wordID path string true "Word ID (UUID)"
+// @Success 200 {object} views.SuccessResponse
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /collections/{id}/words/{wordID} [delete]
+func (s *App) deleteUserWord() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromContext(r.Context())
+
+		collectionID, wordID := r.PathValue("id"), r.PathValue("wordID")
+		if err := validateWordPath(collectionID, wordID); err != nil {
+			s.logger.Error("handlers.deleteUserWord", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		if err := s.wordsDeleter.DeleteWord(r.Context(), collectionID, wordID, userID); err != nil {
+			s.logger.Error("handlers.deleteUserWord", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, map[string]string{"message": "Word deleted successfully"}, nil)
+	}
+}
+
+// deleteUserWords godoc
+// @Summary Delete words
+// @Description Delete several words from the collection at once. Words that are already deleted or belong to
+// @Description other collections are skipped; the response has the number of deleted words
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body views.DeleteUserWordsRequest true "IDs of the words to delete, at most 500"
+// @Success 200 {object} views.SuccessResponse{data=views.DeleteUserWordsResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /collections/{id}/words/bulk-delete [post]
+func (s *App) deleteUserWords() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromContext(r.Context())
+
+		collectionID := r.PathValue("id")
+		if err := uuid.Validate(collectionID); err != nil {
+			s.logger.Error("handlers.deleteUserWords: invalid uuid", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("collection id: %s", collectionID)))
+			return
+		}
+
+		var req views.DeleteUserWordsRequest
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&req); err != nil {
+			s.logger.Error("handlers.deleteUserWords: failed to decode request", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+			return
+		}
+
+		deleted, err := s.wordsDeleter.DeleteWords(r.Context(), collectionID, userID, req.WordIDs)
+		if err != nil {
+			s.logger.Error("handlers.deleteUserWords", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.DeleteUserWordsResponse{Deleted: deleted}, nil)
+	}
+}
+
+// moveUserWord godoc
+// @Summary Move word
+// @Description Move a word to another collection of the user. The word keeps its review schedule, its review
+// @Description history stays with the source collection
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param wordID path string true "Word ID (UUID)"
+// @Param request body views.TransferUserWordRequest true "Target collection"
+// @Success 200 {object} views.SuccessResponse{data=views.UserWordResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /collections/{id}/words/{wordID}/move [post]
+func (s *App) moveUserWord() http.HandlerFunc {
+	return s.transferUserWord("handlers.moveUserWord", false)
+}
+
+// copyUserWord godoc
+// @Summary Copy word
+// @Description Add a copy of a word to another collection of the user. The copy is studied from the beginning
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param wordID path string true "Word ID (UUID)"
+// @Param request body views.TransferUserWordRequest true "Target collection"
+// @Success 200 {object} views.SuccessResponse{data=views.UserWordResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /collections/{id}/words/{wordID}/copy [post]
+func (s *App) copyUserWord() http.HandlerFunc {
+	return s.transferUserWord("handlers.copyUserWord", true)
+}
+
+func (s *App) transferUserWord(name string, asCopy bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromContext(r.Context())
+
+		collectionID, wordID := r.PathValue("id"), r.PathValue("wordID")
+		if err := validateWordPath(collectionID, wordID); err != nil {
+			s.logger.Error(name, zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		var req views.TransferUserWordRequest
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&req); err != nil {
+			s.logger.Error(name+": failed to decode request", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+			return
+		}
+
+		if err := uuid.Validate(req.TargetCollectionID); err != nil {
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, "target_collection_id: "+req.TargetCollectionID))
+			return
+		}
+
+		word, err := s.wordTransferrer.TransferWord(r.Context(), collectionID, wordID, req.TargetCollectionID, userID, asCopy)
+		if err != nil {
+			s.logger.Error(name, zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewUserWordResponse(word), nil)
+	}
+}
+
+// validateWordPath checks the collection and word IDs of the /collections/{id}/words/{wordID} routes.
+func validateWordPath(collectionID, wordID string) error {
+	if err := uuid.Validate(collectionID); err != nil {
+		return errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("collection id: %s", collectionID))
+	}
+
+	if err := uuid.Validate(wordID); err != nil {
+		return errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("word id: %s", wordID))
+	}
+
+	return nil
+}
+
+// getReviewQueue godoc
+// @Summary Get review queue
+// @Description Get the words of the collection due for review, the longest overdue first. New words are due
+// @Description right after they are added; the next review date of a word is set by POST /words/{wordID}/review
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param limit query int false "Number of words to return" default(20)
+// @Success 200 {object} views.SuccessResponse{data=views.ReviewQueueResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /collections/{id}/review [get]
+func (s *App) getReviewQueue() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromContext(r.Context())
+
+		collectionID := r.PathValue("id")
+		if err := uuid.Validate(collectionID); err != nil {
+			s.logger.Error("handlers.getReviewQueue: invalid uuid", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("collection id: %s", collectionID)))
+			return
+		}
+
+		limit := 20
+
+		if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+			if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+				limit = parsedLimit
+			}
+		}
+
+		queue, err := s.reviewQueueGetter.GetReviewQueue(r.Context(), collectionID, userID, limit)
+		if err != nil {
+			s.logger.Error("handlers.getReviewQueue", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewReviewQueueResponse(queue), nil)
+	}
+}
+
+// getWordSuggestions godoc
+// @Summary Get word suggestions
+// @Description Get new words for the collection suggested by the AI from the words it already has. The suggestions
+// @Description are cached and generated again when they are older than the TTL, which takes a few seconds
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Success 200 {object} views.SuccessResponse{data=views.WordSuggestionsResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /collections/{id}/suggestions [get]
+func (s *App) getWordSuggestions() http.HandlerFunc {
+	return s.wordSuggestions("handlers.getWordSuggestions", false)
+}
+
+// refreshWordSuggestions godoc
+// @Summary Refresh word suggestions
+// @Description Generate new word suggestions for the collection regardless of the age of the cached ones
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Success 200 {object} views.SuccessResponse{data=views.WordSuggestionsResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /collections/{id}/suggestions/refresh [post]
+func (s *App) refreshWordSuggestions() http.HandlerFunc {
+	return s.wordSuggestions("handlers.refreshWordSuggestions", true)
+}
+
+func (s *App) wordSuggestions(name string, refresh bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromContext(r.Context())
+
+		collectionID := r.PathValue("id")
+		if err := uuid.Validate(collectionID); err != nil {
+			s.logger.Error(name+": invalid uuid", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("collection id: %s", collectionID)))
+			return
+		}
+
+		suggestions, err := s.suggestionsGetter.GetSuggestions(r.Context(), collectionID, userID, refresh)
+		if err != nil {
+			s.logger.Error(name, zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewWordSuggestionsResponse(suggestions), nil)
+	}
+}
+
+// acceptWordSuggestion godoc
+// @Summary Accept word suggestion
+// @Description Add a suggested word with its translation to the collection and remove it from the suggestions
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body views.AcceptWordSuggestionRequest true "Suggested word"
+// @Success 200 {object} views.SuccessResponse{data=views.AddWordToCollectionResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 409 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /collections/{id}/suggestions/accept [post]
+func (s *App) acceptWordSuggestion() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromContext(r.Context())
+
+		collectionID := r.PathValue("id")
+		if err := uuid.Validate(collectionID); err != nil {
+			s.logger.Error("handlers.acceptWordSuggestion: invalid uuid", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("collection id: %s", collectionID)))
+			return
+		}
+
+		var req views.AcceptWordSuggestionRequest
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&req); err != nil {
+			s.logger.Error("handlers.acceptWordSuggestion: failed to decode request", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+			return
+		}
+
+		if req.Word == "" {
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, "word is required"))
+			return
+		}
+
+		word, err := s.suggestionAccepter.AcceptSuggestion(r.Context(), collectionID, userID, req.Word)
+		if err != nil {
+			s.logger.Error("handlers.acceptWordSuggestion", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewAddWordToCollectionResponse(word), nil)
+	}
+}
+
+// reviewWord godoc
+// @Summary Review word
+// @Description Record how well the user recalled the word and schedule its next review with the SM-2 algorithm.
+// @Description A grade below 3 is a lapse: the word comes back the next day. The word is learned while its
+// @Description interval reaches the mastery threshold, and the review continues the study streak of the collection
+// @Description by calendar day in the user's time zone. The grade is applied to the state the word had when
+// @Description the request was read, so a repeated submission of the same review returns 409
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param wordID path string true "Word ID (UUID)"
+// @Param request body views.ReviewWordRequest true "Recall grade from 0 to 5"
+// @Success 200 {object} views.SuccessResponse{data=views.ReviewWordResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Failure 409 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /words/{wordID}/review [post]
+func (s *App) reviewWord() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromContext(r.Context())
+
+		wordID := r.PathValue("wordID")
+		if err := uuid.Validate(wordID); err != nil {
+			s.logger.Error("handlers.reviewWord: invalid uuid", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrTypeMustBeUUID, fmt.Sprintf("word id: %s", wordID)))
+			return
+		}
+
+		var req views.ReviewWordRequest
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&req); err != nil {
+			s.logger.Error("handlers.reviewWord: failed to decode request", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+			return
+		}
+
+		if req.Grade == nil {
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, "grade is required"))
+			return
+		}
+
+		word, err := s.wordReviewer.ReviewWord(r.Context(), wordID, userID, *req.Grade)
+		if err != nil {
+			s.logger.Error("handlers.reviewWord", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewReviewWordResponse(word), nil)
+	}
+}
+
+// previewPrompt godoc
+// @Summary Preview prompt
+// @Description Render a prompt with the given answers exactly as it would be sent to the model. The stored
+// @Description template is selected by name and version (the latest by default); a template in the body is
+// @Description rendered instead, to check a new version before adding it. Only session_analysis can be previewed
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body views.PreviewPromptRequest true "Prompt and sample answers"
+// @Success 200 {object} views.SuccessResponse{data=views.PreviewPromptResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 404 {object} views.ErrorResponse{error=views.Error}
+// @Security AdminToken
+// @Router /admin/prompts/preview [post]
+func (s *App) previewPrompt() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req views.PreviewPromptRequest
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&req); err != nil {
+			s.logger.Error("handlers.previewPrompt", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+			return
+		}
+
+		preview := entity.PromptPreview{
+			Prompt: entity.PromptVersion{
+				Name:    req.Name,
+				Version: req.Version,
+			},
+			Template:         req.Template,
+			FeedbackLanguage: req.FeedbackLanguage,
+			Data: entity.AnalysisPromptData{
+				Answers: make([]entity.PromptAnswer, 0, len(req.Answers)),
+			},
+		}
+
+		for _, answer := range req.Answers {
+			preview.Data.Answers = append(preview.Data.Answers, entity.PromptAnswer{
+				ID:         answer.AnswerID,
+				Question:   answer.Question,
+				Transcript: answer.Transcript,
+			})
+		}
+
+		prompt, rendered, err := s.promptPreviewer.PreviewPrompt(r.Context(), preview)
+		if err != nil {
+			s.logger.Error("handlers.previewPrompt", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewPreviewPromptResponse(prompt, rendered), nil)
+	}
+}
+
+// getUserSettings godoc
+// @Summary Get user settings
+// @Description Get the settings of the authenticated user
+// @Tags user
+// @Produce json
+// @Success 200 {object} views.SuccessResponse{data=views.UserSettingsResponse}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /me/settings [get]
+func (s *App) getUserSettings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromContext(r.Context())
+
+		settings, err := s.userSettingsGetter.GetSettings(r.Context(), userID)
+		if err != nil {
+			s.logger.Error("handlers.getUserSettings", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewUserSettingsResponse(settings), nil)
+	}
+}
+
+// updateUserSettings godoc
+// @Summary Update user settings
+// @Description Change the settings of the authenticated user; missing fields keep their values.
+// @Description feedback_language is the language of explanations and feedback in session analyses,
+// @Description time_zone (IANA name) sets the calendar days of study streaks
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body views.UpdateUserSettingsRequest true "Settings to change"
+// @Success 200 {object} views.SuccessResponse{data=views.UserSettingsResponse}
+// @Failure 400 {object} views.ErrorResponse{error=views.Error}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /me/settings [patch]
+func (s *App) updateUserSettings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req views.UpdateUserSettingsRequest
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&req); err != nil {
+			s.logger.Error("handlers.updateUserSettings", zap.Error(err))
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrDecodingJSON, err.Error()))
+			return
+		}
+
+		userID := userIDFromContext(r.Context())
+
+		settings, err := s.userSettingsUpdater.UpdateSettings(r.Context(), userID, entity.UserSettingsUpdate{
+			FeedbackLanguage: req.FeedbackLanguage,
+			TimeZone:         req.TimeZone,
+		})
+		if err != nil {
+			s.logger.Error("handlers.updateUserSettings", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewUserSettingsResponse(settings), nil)
+	}
+}
+
+// getUserProgress godoc
+// @Summary Get progress
+// @Description Get the metrics of the last completed sessions, oldest first, with the current level estimated
+// @Description over the recent sessions and the trend of every skill: level, grammar (issues per answer),
+// @Description vocabulary (mean level of the top words), fluency (words per minute) and fillers (share of words)
+// @Tags user
+// @Produce json
+// @Param limit query int false "Number of sessions in the series" default(30)
+// @Success 200 {object} views.SuccessResponse{data=views.ProgressResponse}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /me/progress [get]
+func (s *App) getUserProgress() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 30
+
+		if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+			if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+				limit = parsedLimit
+			}
+		}
+
+		userID := userIDFromContext(r.Context())
+
+		progress, err := s.userProgressGetter.GetProgress(r.Context(), userID, limit)
+		if err != nil {
+			s.logger.Error("handlers.getUserProgress", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewProgressResponse(progress), nil)
+	}
+}
+
+// getGrammarMistakes godoc
+// @Summary Get recurring grammar mistakes
+// @Description Rank the grammar rule categories the user breaks most often in session answers. Every category
+// @Description has the latest examples and the articles covering the rule, closest to the user's level first
+// @Tags user
+// @Produce json
+// @Param limit query int false "Number of categories to return" default(10)
+// @Success 200 {object} views.SuccessResponse{data=views.GrammarMistakesResponse}
+// @Failure 401 {object} views.ErrorResponse{error=views.Error}
+// @Failure 500 {object} views.ErrorResponse{error=views.Error}
+// @Security BearerAuth
+// @Router /me/grammar-mistakes [get]
+func (s *App) getGrammarMistakes() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 10
+
+		if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+			if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+				limit = parsedLimit
+			}
+		}
+
+		userID := userIDFromContext(r.Context())
+
+		categories, err := s.grammarMistakesGetter.GetMistakes(r.Context(), userID, limit)
+		if err != nil {
+			s.logger.Error("handlers.getGrammarMistakes", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		views.Return(s.logger, w, r, views.NewGrammarMistakesResponse(categories), nil)
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"speech-processing-service/internal/app/views"
+	"speech-processing-service/internal/errs"
+
+	"go.uber.org/zap"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+
+	adminTokenHeader = "X-Admin-Token"
+
+	upgradeHeader  = "Upgrade"
+	tokenQueryKey  = "token"
+	websocketProto = "websocket"
+)
+
+type ctxKey int
+
+const (
+	userIDCtxKey ctxKey = iota
+)
+
+// authorized validates the bearer token and puts the user ID into the request context.
+func (s *App) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			s.logger.Error("middleware.authorized: missing bearer token")
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrUnauthorized, "missing bearer token"))
+			return
+		}
+
+		userID, err := s.tokenParser.ParseToken(token)
+		if err != nil {
+			s.logger.Error("middleware.authorized", zap.Error(err))
+			views.Return(s.logger, w, r, nil, err)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), userIDCtxKey, userID)))
+	}
+}
+
+// bearerToken reads the token from the Authorization header. Browsers can't set headers on WebSocket
+// connections, so WebSocket upgrades may pass it in the token query parameter instead.
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get(authorizationHeader)
+	if strings.HasPrefix(header, bearerPrefix) {
+		return strings.TrimPrefix(header, bearerPrefix), true
+	}
+
+	if strings.EqualFold(r.Header.Get(upgradeHeader), websocketProto) {
+		if token := r.URL.Query().Get(tokenQueryKey); token != "" {
+			return token, true
+		}
+	}
+
+	return "", false
+}
+
+// admin lets the request through only with the configured admin token. Without the token
+// in the config the admin endpoints are disabled.
+func (s *App) admin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		expected := s.cfg.Auth.AdminToken
+		token := r.Header.Get(adminTokenHeader)
+
+		if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			s.logger.Error("middleware.admin: invalid admin token")
+			views.Return(s.logger, w, r, nil, errs.New(errs.ErrUnauthorized, "invalid admin token"))
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func userIDFromContext(ctx context.Context) int {
+	userID, _ := ctx.Value(userIDCtxKey).(int)
+
+	return userID
+}
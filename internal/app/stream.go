@@ -0,0 +1,70 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"speech-processing-service/internal/app/views"
+	"speech-processing-service/internal/errs"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// maxAudioFrame caps a single websocket message; live audio comes in chunks of a few kilobytes
+	maxAudioFrame = 1 << 20
+)
+
+type frame struct {
+	payloadType byte
+	data        []byte
+}
+
+// frameCodec keeps the frame type, which websocket.Message loses: audio comes in binary frames,
+// control messages in text ones.
+var frameCodec = websocket.Codec{
+	Unmarshal: func(data []byte, payloadType byte, v interface{}) error {
+		f := v.(*frame)
+		f.payloadType = payloadType
+		f.data = data
+
+		return nil
+	},
+}
+
+// receiveAudio returns the next audio chunk and io.EOF when the learner finishes, disconnects or stays silent
+// for longer than the idle timeout.
+func (s *App) receiveAudio(ws *websocket.Conn) ([]byte, error) {
+	for {
+		// Дедлайн на каждое сообщение: молчащий клиент не держит соединение вечно
+		if err := ws.SetReadDeadline(time.Now().Add(s.cfg.Answers.StreamIdle)); err != nil {
+			return nil, errs.New(errs.ErrUseCaseExecution, "ws.SetReadDeadline: "+err.Error())
+		}
+
+		var f frame
+		if err := frameCodec.Receive(ws, &f); err != nil {
+			if errors.Is(err, websocket.ErrFrameTooLarge) {
+				return nil, errs.New(errs.ErrInvalidAudio, "audio message is too large")
+			}
+
+			// Обрыв соединения и простой тоже завершают ответ: записанное сохраняется
+			if err != io.EOF {
+				s.logger.Warn("app.receiveAudio", zap.Error(err))
+			}
+
+			return nil, io.EOF
+		}
+
+		if f.payloadType == websocket.BinaryFrame {
+			return f.data, nil
+		}
+
+		var control views.StreamControl
+		if err := json.Unmarshal(f.data, &control); err == nil && control.Type == views.StreamControlFinish {
+			return nil, io.EOF
+		}
+	}
+}
@@ -9,3 +9,67 @@ type AddWordToCollectionRequest struct {
 	Translation string  `json:"translation"`
 	Example     *string `json:"example"`
 }
+
+type ReviewWordRequest struct {
+	// Grade is the SM-2 recall grade: 0 - forgot completely, 3 - recalled with difficulty, 5 - perfect recall
+	Grade *int `json:"grade" example:"4"`
+}
+
+type AcceptWordSuggestionRequest struct {
+	Word string `json:"word" example:"itinerary"`
+}
+
+// UpdateUserWordRequest changes only the fields that are present; an empty example removes it.
+type UpdateUserWordRequest struct {
+	Word        *string `json:"word" example:"itinerary"`
+	Translation *string `json:"translation" example:"маршрут"`
+	Example     *string `json:"example" example:"Our itinerary includes three cities."`
+}
+
+// UpdateWordCollectionRequest is the JSON form of the collection update; the image is sent only as multipart.
+type UpdateWordCollectionRequest struct {
+	Name        *string `json:"name" example:"Travel"`
+	RemoveImage bool    `json:"remove_image" example:"false"`
+}
+
+type DeleteUserWordsRequest struct {
+	WordIDs []string `json:"word_ids"`
+}
+
+type TransferUserWordRequest struct {
+	TargetCollectionID string `json:"target_collection_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+type AuthRequest struct {
+	Email    string `json:"email" example:"learner@example.com"`
+	Password string `json:"password" example:"secret123"`
+}
+
+const StreamControlFinish = "finish"
+
+// StreamControl is a text message of the answer stream; audio goes in binary messages.
+type StreamControl struct {
+	Type string `json:"type" example:"finish"`
+}
+
+type PromptAnswerRequest struct {
+	AnswerID   int    `json:"answer_id" example:"12"`
+	Question   string `json:"question" example:"What was your last trip?"`
+	Transcript string `json:"transcript" example:"I have went to Spain last year."`
+}
+
+// PreviewPromptRequest renders the stored prompt or, when Template is set, a draft of it.
+type PreviewPromptRequest struct {
+	Name             string                `json:"name" example:"session_analysis"`
+	Version          string                `json:"version" example:"v2"`
+	Template         string                `json:"template"`
+	FeedbackLanguage string                `json:"feedback_language" example:"ru"`
+	Answers          []PromptAnswerRequest `json:"answers"`
+}
+
+// UpdateUserSettingsRequest changes only the fields that are present.
+type UpdateUserSettingsRequest struct {
+	FeedbackLanguage *string `json:"feedback_language" enums:"en,ru,be" example:"ru"`
+	// TimeZone is an IANA time zone name
+	TimeZone *string `json:"time_zone" example:"Europe/Minsk"`
+}
@@ -102,16 +102,71 @@ type CompleteSessionResp struct {
 		Word  string `json:"word"`
 		Level string `json:"level"`
 	} `json:"top_words"`
-	GrammarIssues []struct {
-		Sentence          string `json:"sentence"`
-		Explanation       string `json:"explanation"`
-		CorrectedSentence string `json:"corrected_sentence"`
-	} `json:"grammar_issues"`
+	GrammarIssues       []GrammarIssueDTO `json:"grammar_issues"`
 	RephraseSuggestions []struct {
 		Original   string `json:"original"`
 		Suggestion string `json:"suggestion"`
 	} `json:"rephrase_suggestions"`
-	OverallFeedback string `json:"overall_feedback"`
+	OverallFeedback string              `json:"overall_feedback"`
+	Answers         []AnswerFeedbackDTO `json:"answers"`
+	Fluency         []FluencyDTO        `json:"fluency"`
+	Prompt          *PromptVersionDTO   `json:"prompt"`
+	// FeedbackLanguage is the language of the explanations and feedback
+	FeedbackLanguage string `json:"feedback_language" example:"ru"`
+}
+
+type GrammarIssueDTO struct {
+	Sentence          string `json:"sentence" example:"I have went there last year."`
+	Explanation       string `json:"explanation" example:"Past Simple is used for finished actions at a stated time."`
+	CorrectedSentence string `json:"corrected_sentence" example:"I went there last year."`
+	Category          string `json:"category" example:"tenses"`
+	AnswerID          int    `json:"answer_id" example:"12"`
+}
+
+type AnswerFeedbackDTO struct {
+	AnswerID      int               `json:"answer_id" example:"12"`
+	QuestionID    int               `json:"question_id" example:"3"`
+	Question      string            `json:"question" example:"What was your last trip?"`
+	Transcript    string            `json:"transcript" example:"I have went to Spain last year."`
+	Relevance     string            `json:"relevance" enums:"relevant,partially_relevant,off_topic" example:"relevant"`
+	Level         string            `json:"level" example:"B1"`
+	GrammarIssues []GrammarIssueDTO `json:"grammar_issues"`
+	Feedback      string            `json:"feedback" example:"You answered the question, but mixed up the tenses."`
+}
+
+func newGrammarIssueDTOs(issues []entity.GrammarIssue) []GrammarIssueDTO {
+	dtos := make([]GrammarIssueDTO, 0, len(issues))
+	for _, issue := range issues {
+		dtos = append(dtos, GrammarIssueDTO{
+			Sentence:          issue.Sentence,
+			Explanation:       issue.Explanation,
+			CorrectedSentence: issue.CorrectedSentence,
+			Category:          issue.Category,
+			AnswerID:          issue.AnswerID,
+		})
+	}
+
+	return dtos
+}
+
+type LowConfidenceWordDTO struct {
+	Word       string  `json:"word" example:"through"`
+	Confidence float64 `json:"confidence" example:"0.42"`
+	Start      float64 `json:"start" example:"3.12"`
+}
+
+type FluencyDTO struct {
+	AnswerID            int                    `json:"answer_id" example:"12"`
+	QuestionID          int                    `json:"question_id" example:"3"`
+	WordsCount          int                    `json:"words_count" example:"84"`
+	SpeakingSeconds     float64                `json:"speaking_seconds" example:"41.5"`
+	WordsPerMinute      float64                `json:"words_per_minute" example:"121.45"`
+	PauseCount          int                    `json:"pause_count" example:"4"`
+	TotalPauseSeconds   float64                `json:"total_pause_seconds" example:"3.8"`
+	LongestPauseSeconds float64                `json:"longest_pause_seconds" example:"1.6"`
+	FillerCount         int                    `json:"filler_count" example:"3"`
+	FillerRate          float64                `json:"filler_rate" example:"0.04"`
+	LowConfidenceWords  []LowConfidenceWordDTO `json:"low_confidence_words"`
 }
 
 func NewCompleteSessionResp(result *entity.AnalyzeTextResult) CompleteSessionResp {
@@ -120,11 +175,7 @@ func NewCompleteSessionResp(result *entity.AnalyzeTextResult) CompleteSessionRes
 			Word  string `json:"word"`
 			Level string `json:"level"`
 		}, 0, len(result.TopWords)),
-		GrammarIssues: make([]struct {
-			Sentence          string `json:"sentence"`
-			Explanation       string `json:"explanation"`
-			CorrectedSentence string `json:"corrected_sentence"`
-		}, 0, len(result.GrammarIssues)),
+		GrammarIssues: newGrammarIssueDTOs(result.GrammarIssues),
 		RephraseSuggestions: make([]struct {
 			Original   string `json:"original"`
 			Suggestion string `json:"suggestion"`
@@ -143,18 +194,6 @@ func NewCompleteSessionResp(result *entity.AnalyzeTextResult) CompleteSessionRes
 		})
 	}
 
-	for _, issue := range result.GrammarIssues {
-		analyzeTextResp.GrammarIssues = append(analyzeTextResp.GrammarIssues, struct {
-			Sentence          string `json:"sentence"`
-			Explanation       string `json:"explanation"`
-			CorrectedSentence string `json:"corrected_sentence"`
-		}{
-			Sentence:          issue.Sentence,
-			Explanation:       issue.Explanation,
-			CorrectedSentence: issue.CorrectedSentence,
-		})
-	}
-
 	for _, suggestion := range result.RephraseSuggestions {
 		analyzeTextResp.RephraseSuggestions = append(analyzeTextResp.RephraseSuggestions, struct {
 			Original   string `json:"original"`
@@ -167,9 +206,152 @@ func NewCompleteSessionResp(result *entity.AnalyzeTextResult) CompleteSessionRes
 
 	analyzeTextResp.OverallFeedback = result.OverallFeedback
 
+	analyzeTextResp.Answers = make([]AnswerFeedbackDTO, 0, len(result.Answers))
+	for _, answer := range result.Answers {
+		analyzeTextResp.Answers = append(analyzeTextResp.Answers, AnswerFeedbackDTO{
+			AnswerID:      answer.AnswerID,
+			QuestionID:    answer.QuestionID,
+			Question:      answer.Question,
+			Transcript:    answer.Transcript,
+			Relevance:     answer.Relevance,
+			Level:         answer.Level,
+			GrammarIssues: newGrammarIssueDTOs(answer.GrammarIssues),
+			Feedback:      answer.Feedback,
+		})
+	}
+
+	analyzeTextResp.FeedbackLanguage = result.FeedbackLanguage
+	if analyzeTextResp.FeedbackLanguage == "" {
+		analyzeTextResp.FeedbackLanguage = entity.DefaultFeedbackLanguage
+	}
+
+	if result.Prompt != nil {
+		analyzeTextResp.Prompt = &PromptVersionDTO{
+			Name:    result.Prompt.Name,
+			Version: result.Prompt.Version,
+		}
+	}
+
+	analyzeTextResp.Fluency = make([]FluencyDTO, 0, len(result.Fluency))
+	for _, fluency := range result.Fluency {
+		lowConfidenceWords := make([]LowConfidenceWordDTO, 0, len(fluency.LowConfidenceWords))
+		for _, word := range fluency.LowConfidenceWords {
+			lowConfidenceWords = append(lowConfidenceWords, LowConfidenceWordDTO{
+				Word:       word.Word,
+				Confidence: word.Confidence,
+				Start:      word.Start,
+			})
+		}
+
+		analyzeTextResp.Fluency = append(analyzeTextResp.Fluency, FluencyDTO{
+			AnswerID:            fluency.AnswerID,
+			QuestionID:          fluency.QuestionID,
+			WordsCount:          fluency.WordsCount,
+			SpeakingSeconds:     fluency.SpeakingSeconds,
+			WordsPerMinute:      fluency.WordsPerMinute,
+			PauseCount:          fluency.PauseCount,
+			TotalPauseSeconds:   fluency.TotalPauseSeconds,
+			LongestPauseSeconds: fluency.LongestPauseSeconds,
+			FillerCount:         fluency.FillerCount,
+			FillerRate:          fluency.FillerRate,
+			LowConfidenceWords:  lowConfidenceWords,
+		})
+	}
+
 	return analyzeTextResp
 }
 
+type SessionSummaryDTO struct {
+	ID           string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TopicID      int     `json:"topic_id" example:"1"`
+	TopicTitle   string  `json:"topic_title" example:"Travelling"`
+	Status       string  `json:"status" example:"completed"`
+	OverallLevel *string `json:"overall_level" example:"B1"`
+	AnswersCount int     `json:"answers_count" example:"3"`
+	CreatedAt    string  `json:"created_at"`
+	CompletedAt  *string `json:"completed_at"`
+}
+
+type GetUserSessionsResponse struct {
+	Sessions []SessionSummaryDTO `json:"sessions"`
+}
+
+func NewGetUserSessionsResponse(sessions []entity.SessionSummary) GetUserSessionsResponse {
+	resp := GetUserSessionsResponse{
+		Sessions: make([]SessionSummaryDTO, 0, len(sessions)),
+	}
+
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, SessionSummaryDTO{
+			ID:           session.ID,
+			TopicID:      session.TopicID,
+			TopicTitle:   session.TopicTitle,
+			Status:       session.Status,
+			OverallLevel: session.OverallLevel,
+			AnswersCount: session.AnswersCount,
+			CreatedAt:    session.CreatedAt,
+			CompletedAt:  session.CompletedAt,
+		})
+	}
+
+	return resp
+}
+
+type SessionAnswerDTO struct {
+	ID           int     `json:"id"`
+	QuestionID   int     `json:"question_id"`
+	QuestionText string  `json:"question_text"`
+	AudioURL     string  `json:"audio_url"`
+	Transcript   *string `json:"transcript"`
+}
+
+type SessionDetailDTO struct {
+	ID          string               `json:"id"`
+	TopicID     int                  `json:"topic_id"`
+	TopicTitle  string               `json:"topic_title"`
+	Status      string               `json:"status"`
+	Answers     []SessionAnswerDTO   `json:"answers"`
+	Analysis    *CompleteSessionResp `json:"analysis"`
+	CreatedAt   string               `json:"created_at"`
+	CompletedAt *string              `json:"completed_at"`
+}
+
+type SessionDetailResponse struct {
+	Session SessionDetailDTO `json:"session"`
+}
+
+func NewSessionDetailResponse(detail entity.SessionDetail) SessionDetailResponse {
+	answers := make([]SessionAnswerDTO, 0, len(detail.Answers))
+	for _, answer := range detail.Answers {
+		answers = append(answers, SessionAnswerDTO{
+			ID:           answer.ID,
+			QuestionID:   answer.QuestionID,
+			QuestionText: answer.QuestionText,
+			AudioURL:     answer.AudioURL,
+			Transcript:   answer.Transcript,
+		})
+	}
+
+	var analysis *CompleteSessionResp
+	if detail.Analysis != nil {
+		resp := NewCompleteSessionResp(detail.Analysis)
+		analysis = &resp
+	}
+
+	return SessionDetailResponse{
+		Session: SessionDetailDTO{
+			ID:          detail.ID,
+			TopicID:     detail.TopicID,
+			TopicTitle:  detail.TopicTitle,
+			Status:      detail.Status,
+			Answers:     answers,
+			Analysis:    analysis,
+			CreatedAt:   detail.CreatedAt,
+			CompletedAt: detail.CompletedAt,
+		},
+	}
+}
+
 type ArticlePreview struct {
 	ID            int    `json:"id"`
 	ImageURL      string `json:"image_url"`
@@ -292,6 +474,24 @@ func NewCreateWordCollectionResponse(collection entity.WordCollection) CreateWor
 	return resp
 }
 
+type UpdateWordCollectionResponse struct {
+	Collection WordCollectionResponse `json:"collection"`
+}
+
+func NewUpdateWordCollectionResponse(collection entity.WordCollection) UpdateWordCollectionResponse {
+	return UpdateWordCollectionResponse{
+		Collection: WordCollectionResponse{
+			ID:                collection.ID,
+			Name:              collection.Name,
+			ImageURL:          collection.ImageURL,
+			TotalWords:        collection.TotalWordsCount,
+			LearnedWords:      collection.LearnedWordsCount,
+			CurrentStreakDays: collection.CurrentStreakDays,
+			CreatedAt:         collection.CreatedAt,
+		},
+	}
+}
+
 type GetUserCollectionsResponse struct {
 	Collections []WordCollectionResponse `json:"collections"`
 }
@@ -334,6 +534,29 @@ type UserWordDTO struct {
 	Example        *string `json:"example"`
 	NextReviewDate string  `json:"next_review_date"`
 	ReviewCount    int     `json:"review_count"`
+	EaseFactor     float64 `json:"ease_factor"`
+	IntervalDays   int     `json:"interval_days"`
+	Repetitions    int     `json:"repetitions"`
+	Lapses         int     `json:"lapses"`
+	LastReviewedAt *string `json:"last_reviewed_at"`
+	LearnedAt      *string `json:"learned_at"`
+}
+
+func newUserWordDTO(word entity.UserWord) UserWordDTO {
+	return UserWordDTO{
+		ID:             word.ID,
+		Word:           word.Word,
+		Translation:    word.Translation,
+		Example:        word.Example,
+		NextReviewDate: word.NextReviewDate,
+		ReviewCount:    word.ReviewCount,
+		EaseFactor:     word.Schedule.EaseFactor,
+		IntervalDays:   word.Schedule.IntervalDays,
+		Repetitions:    word.Schedule.Repetitions,
+		Lapses:         word.Schedule.Lapses,
+		LastReviewedAt: word.LastReviewedAt,
+		LearnedAt:      word.LearnedAt,
+	}
 }
 
 type AISuggestionDTO struct {
@@ -345,14 +568,7 @@ type AISuggestionDTO struct {
 func NewWordCollectionDetailResponse(detail entity.WordCollectionDetail) WordCollectionDetailResponse {
 	userWords := make([]UserWordDTO, 0, len(detail.UserWords))
 	for _, word := range detail.UserWords {
-		userWords = append(userWords, UserWordDTO{
-			ID:             word.ID,
-			Word:           word.Word,
-			Translation:    word.Translation,
-			Example:        word.Example,
-			NextReviewDate: word.NextReviewDate,
-			ReviewCount:    word.ReviewCount,
-		})
+		userWords = append(userWords, newUserWordDTO(word))
 	}
 
 	aiSuggestions := make([]AISuggestionDTO, 0, len(detail.AISuggestions))
@@ -381,13 +597,390 @@ type AddWordToCollectionResponse struct {
 
 func NewAddWordToCollectionResponse(word entity.UserWord) AddWordToCollectionResponse {
 	return AddWordToCollectionResponse{
-		Word: UserWordDTO{
-			ID:             word.ID,
-			Word:           word.Word,
-			Translation:    word.Translation,
-			Example:        word.Example,
-			NextReviewDate: word.NextReviewDate,
-			ReviewCount:    word.ReviewCount,
+		Word: newUserWordDTO(word),
+	}
+}
+
+type UserWordResponse struct {
+	Word UserWordDTO `json:"word"`
+}
+
+func NewUserWordResponse(word entity.UserWord) UserWordResponse {
+	return UserWordResponse{
+		Word: newUserWordDTO(word),
+	}
+}
+
+type DeleteUserWordsResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+type WordSuggestionsResponse struct {
+	CollectionID string            `json:"collection_id"`
+	GeneratedAt  string            `json:"generated_at"`
+	Suggestions  []AISuggestionDTO `json:"suggestions"`
+}
+
+func NewWordSuggestionsResponse(suggestions entity.WordSuggestions) WordSuggestionsResponse {
+	resp := WordSuggestionsResponse{
+		CollectionID: suggestions.CollectionID,
+		GeneratedAt:  suggestions.GeneratedAt,
+		Suggestions:  make([]AISuggestionDTO, 0, len(suggestions.Suggestions)),
+	}
+
+	for _, suggestion := range suggestions.Suggestions {
+		resp.Suggestions = append(resp.Suggestions, AISuggestionDTO{
+			Word:        suggestion.Word,
+			Translation: suggestion.Translation,
+			Reason:      suggestion.Reason,
+		})
+	}
+
+	return resp
+}
+
+type ReviewQueueResponse struct {
+	CollectionID string `json:"collection_id"`
+	// DueCount is the number of all due words, the queue holds up to limit of them
+	DueCount int           `json:"due_count"`
+	Words    []UserWordDTO `json:"words"`
+}
+
+func NewReviewQueueResponse(queue entity.ReviewQueue) ReviewQueueResponse {
+	words := make([]UserWordDTO, 0, len(queue.Words))
+	for _, word := range queue.Words {
+		words = append(words, newUserWordDTO(word))
+	}
+
+	return ReviewQueueResponse{
+		CollectionID: queue.CollectionID,
+		DueCount:     queue.DueCount,
+		Words:        words,
+	}
+}
+
+type ReviewWordResponse struct {
+	Word UserWordDTO `json:"word"`
+}
+
+func NewReviewWordResponse(word entity.UserWord) ReviewWordResponse {
+	return ReviewWordResponse{
+		Word: newUserWordDTO(word),
+	}
+}
+
+type UserDTO struct {
+	ID        int    `json:"id"`
+	Email     string `json:"email"`
+	CreatedAt string `json:"created_at"`
+}
+
+type AuthResponse struct {
+	Token     string  `json:"token"`
+	ExpiresAt string  `json:"expires_at"`
+	User      UserDTO `json:"user"`
+}
+
+func NewAuthResponse(token entity.AuthToken) AuthResponse {
+	return AuthResponse{
+		Token:     token.Token,
+		ExpiresAt: token.ExpiresAt,
+		User: UserDTO{
+			ID:        token.User.ID,
+			Email:     token.User.Email,
+			CreatedAt: token.User.CreatedAt,
+		},
+	}
+}
+
+type JobDTO struct {
+	ID          string               `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Type        string               `json:"type" example:"complete_session"`
+	Status      string               `json:"status" example:"running"`
+	Progress    int                  `json:"progress" example:"60"`
+	Attempts    int                  `json:"attempts" example:"1"`
+	MaxAttempts int                  `json:"max_attempts" example:"5"`
+	LastError   *string              `json:"last_error"`
+	Result      *CompleteSessionResp `json:"result"`
+	CreatedAt   string               `json:"created_at"`
+	UpdatedAt   string               `json:"updated_at"`
+	FinishedAt  *string              `json:"finished_at"`
+}
+
+type JobResponse struct {
+	Job JobDTO `json:"job"`
+}
+
+func NewJobResponse(job entity.Job) JobResponse {
+	var result *CompleteSessionResp
+	if job.SessionAnalysis != nil {
+		resp := NewCompleteSessionResp(job.SessionAnalysis)
+		result = &resp
+	}
+
+	return JobResponse{
+		Job: JobDTO{
+			ID:          job.ID,
+			Type:        job.Type,
+			Status:      job.Status,
+			Progress:    job.Progress,
+			Attempts:    job.Attempts,
+			MaxAttempts: job.MaxAttempts,
+			LastError:   job.LastError,
+			Result:      result,
+			CreatedAt:   job.CreatedAt,
+			UpdatedAt:   job.UpdatedAt,
+			FinishedAt:  job.FinishedAt,
+		},
+	}
+}
+
+const (
+	StreamMessageInterim = "interim"
+	StreamMessageFinal   = "final"
+	StreamMessageSaved   = "saved"
+	StreamMessageError   = "error"
+)
+
+// StreamMessage is sent to the learner over the answer stream.
+type StreamMessage struct {
+	Type       string `json:"type" enums:"interim,final,saved,error" example:"interim"`
+	Transcript string `json:"transcript,omitempty" example:"I usually spend my weekends"`
+	AnswerID   int    `json:"answer_id,omitempty" example:"12"`
+	Error      *Error `json:"error,omitempty"`
+}
+
+func NewStreamUpdate(update entity.TranscriptUpdate) StreamMessage {
+	messageType := StreamMessageInterim
+	if update.IsFinal {
+		messageType = StreamMessageFinal
+	}
+
+	return StreamMessage{
+		Type:       messageType,
+		Transcript: update.Transcript,
+	}
+}
+
+func NewStreamSaved(answerID int) StreamMessage {
+	return StreamMessage{
+		Type:     StreamMessageSaved,
+		AnswerID: answerID,
+	}
+}
+
+func NewStreamError(err error) StreamMessage {
+	return StreamMessage{
+		Type: StreamMessageError,
+		Error: &Error{
+			ErrorCode: defineErrorCode(err),
+			Msg:       err.Error(),
 		},
 	}
 }
+
+type ReadAloudWordDTO struct {
+	Reference string  `json:"reference" example:"brown"`
+	Spoken    string  `json:"spoken" example:"brawn"`
+	Status    string  `json:"status" enums:"correct,substituted,omitted,inserted" example:"substituted"`
+	Accuracy  float64 `json:"accuracy" example:"72"`
+	Start     float64 `json:"start" example:"1.2"`
+	End       float64 `json:"end" example:"1.56"`
+}
+
+type ReadAloudResponse struct {
+	Reference     string             `json:"reference" example:"The quick brown fox jumps over the lazy dog."`
+	Transcript    string             `json:"transcript" example:"The quick brawn fox jumps over lazy dog."`
+	Accuracy      float64            `json:"accuracy" example:"81.4"`
+	Substitutions int                `json:"substitutions" example:"1"`
+	Omissions     int                `json:"omissions" example:"1"`
+	Insertions    int                `json:"insertions" example:"0"`
+	Words         []ReadAloudWordDTO `json:"words"`
+}
+
+func NewReadAloudResponse(result entity.ReadAloudResult) ReadAloudResponse {
+	words := make([]ReadAloudWordDTO, 0, len(result.Words))
+	for _, word := range result.Words {
+		words = append(words, ReadAloudWordDTO{
+			Reference: word.Reference,
+			Spoken:    word.Spoken,
+			Status:    word.Status,
+			Accuracy:  word.Accuracy,
+			Start:     word.Start,
+			End:       word.End,
+		})
+	}
+
+	return ReadAloudResponse{
+		Reference:     result.Reference,
+		Transcript:    result.Transcript,
+		Accuracy:      result.Accuracy,
+		Substitutions: result.Substitutions,
+		Omissions:     result.Omissions,
+		Insertions:    result.Insertions,
+		Words:         words,
+	}
+}
+
+type PromptVersionDTO struct {
+	Name    string `json:"name" example:"session_analysis"`
+	Version string `json:"version" example:"v1"`
+}
+
+type PreviewPromptResponse struct {
+	Name    string `json:"name" example:"session_analysis"`
+	Version string `json:"version" example:"v1"`
+	Prompt  string `json:"prompt"`
+}
+
+func NewPreviewPromptResponse(prompt entity.PromptVersion, rendered string) PreviewPromptResponse {
+	return PreviewPromptResponse{
+		Name:    prompt.Name,
+		Version: prompt.Version,
+		Prompt:  rendered,
+	}
+}
+
+type UserSettingsResponse struct {
+	FeedbackLanguage string `json:"feedback_language" enums:"en,ru,be" example:"ru"`
+	TimeZone         string `json:"time_zone" example:"Europe/Minsk"`
+}
+
+func NewUserSettingsResponse(settings entity.UserSettings) UserSettingsResponse {
+	return UserSettingsResponse{
+		FeedbackLanguage: settings.FeedbackLanguage,
+		TimeZone:         settings.TimeZone,
+	}
+}
+
+type ProgressPointDTO struct {
+	SessionID              string         `json:"session_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CompletedAt            string         `json:"completed_at" example:"2025-12-01T17:00:00Z"`
+	OverallLevel           string         `json:"overall_level" example:"B1"`
+	LevelScore             int            `json:"level_score" example:"3"`
+	AnswersCount           int            `json:"answers_count" example:"3"`
+	GrammarIssues          int            `json:"grammar_issues" example:"4"`
+	GrammarIssuesPerAnswer float64        `json:"grammar_issues_per_answer" example:"1.333"`
+	Vocabulary             map[string]int `json:"vocabulary"`
+	VocabularyScore        *float64       `json:"vocabulary_score" example:"3.5"`
+	WordsPerMinute         *float64       `json:"words_per_minute" example:"118.4"`
+	FillerRate             *float64       `json:"filler_rate" example:"0.032"`
+}
+
+type SkillTrendDTO struct {
+	Skill     string   `json:"skill" enums:"level,grammar,vocabulary,fluency,fillers" example:"grammar"`
+	Recent    *float64 `json:"recent" example:"1.2"`
+	Previous  *float64 `json:"previous" example:"2"`
+	Change    *float64 `json:"change" example:"-0.8"`
+	Direction string   `json:"direction" enums:"improving,declining,stable,insufficient_data" example:"improving"`
+}
+
+type ProgressResponse struct {
+	CurrentLevel  *string            `json:"current_level" example:"B1"`
+	CurrentScore  *float64           `json:"current_score" example:"3.4"`
+	SessionsCount int                `json:"sessions_count" example:"12"`
+	Series        []ProgressPointDTO `json:"series"`
+	Trends        []SkillTrendDTO    `json:"trends"`
+}
+
+func NewProgressResponse(progress entity.UserProgress) ProgressResponse {
+	series := make([]ProgressPointDTO, 0, len(progress.Series))
+	for _, point := range progress.Series {
+		series = append(series, ProgressPointDTO{
+			SessionID:              point.SessionID,
+			CompletedAt:            point.CompletedAt,
+			OverallLevel:           point.OverallLevel,
+			LevelScore:             point.LevelScore,
+			AnswersCount:           point.AnswersCount,
+			GrammarIssues:          point.GrammarIssues,
+			GrammarIssuesPerAnswer: point.GrammarIssuesPerAnswer,
+			Vocabulary:             point.Vocabulary,
+			VocabularyScore:        point.VocabularyScore,
+			WordsPerMinute:         point.WordsPerMinute,
+			FillerRate:             point.FillerRate,
+		})
+	}
+
+	trends := make([]SkillTrendDTO, 0, len(progress.Trends))
+	for _, trend := range progress.Trends {
+		trends = append(trends, SkillTrendDTO{
+			Skill:     trend.Skill,
+			Recent:    trend.Recent,
+			Previous:  trend.Previous,
+			Change:    trend.Change,
+			Direction: trend.Direction,
+		})
+	}
+
+	return ProgressResponse{
+		CurrentLevel:  progress.CurrentLevel,
+		CurrentScore:  progress.CurrentScore,
+		SessionsCount: len(progress.Series),
+		Series:        series,
+		Trends:        trends,
+	}
+}
+
+type GrammarMistakeDTO struct {
+	SessionID         string `json:"session_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AnswerID          *int   `json:"answer_id" example:"12"`
+	Sentence          string `json:"sentence" example:"I have went there last year."`
+	CorrectedSentence string `json:"corrected_sentence" example:"I went there last year."`
+	Explanation       string `json:"explanation" example:"Past Simple is used for finished actions at a stated time."`
+	CreatedAt         string `json:"created_at" example:"2025-12-01T17:00:00Z"`
+}
+
+type GrammarMistakeCategoryDTO struct {
+	Category      string              `json:"category" example:"tenses"`
+	Count         int                 `json:"count" example:"7"`
+	SessionsCount int                 `json:"sessions_count" example:"4"`
+	LastSeenAt    string              `json:"last_seen_at" example:"2025-12-01T17:00:00Z"`
+	Examples      []GrammarMistakeDTO `json:"examples"`
+	Articles      []ArticlePreview    `json:"articles"`
+}
+
+type GrammarMistakesResponse struct {
+	Categories []GrammarMistakeCategoryDTO `json:"categories"`
+}
+
+func NewGrammarMistakesResponse(categories []entity.GrammarMistakeCategory) GrammarMistakesResponse {
+	resp := GrammarMistakesResponse{
+		Categories: make([]GrammarMistakeCategoryDTO, 0, len(categories)),
+	}
+
+	for _, category := range categories {
+		examples := make([]GrammarMistakeDTO, 0, len(category.Examples))
+		for _, example := range category.Examples {
+			examples = append(examples, GrammarMistakeDTO{
+				SessionID:         example.SessionID,
+				AnswerID:          example.AnswerID,
+				Sentence:          example.Sentence,
+				CorrectedSentence: example.CorrectedSentence,
+				Explanation:       example.Explanation,
+				CreatedAt:         example.CreatedAt,
+			})
+		}
+
+		articles := make([]ArticlePreview, 0, len(category.Articles))
+		for _, article := range category.Articles {
+			articles = append(articles, ArticlePreview{
+				ID:            article.ID,
+				ImageURL:      article.ImageURL,
+				Level:         article.Level,
+				MinutesToRead: article.MinutesToRead,
+				Title:         article.Title,
+			})
+		}
+
+		resp.Categories = append(resp.Categories, GrammarMistakeCategoryDTO{
+			Category:      category.Category,
+			Count:         category.Count,
+			SessionsCount: category.SessionsCount,
+			LastSeenAt:    category.LastSeenAt,
+			Examples:      examples,
+			Articles:      articles,
+		})
+	}
+
+	return resp
+}
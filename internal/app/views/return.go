@@ -17,10 +17,20 @@ const (
 
 	//bad request group of errors
 	codeTypeMustBeNumeric = 20
+	codeInvalidAudio      = 21
 
 	//not found group of errors
 	codeNotFound = 30
 
+	//unauthorized group of errors
+	codeUnauthorized = 40
+
+	//conflict group of errors
+	codeAlreadyExists    = 50
+	codeSessionCompleted = 51
+	codeReviewConflict   = 52
+	codeAnswerReplaced   = 53
+
 	//unknowError
 	codeUnknown = 999
 )
@@ -31,6 +41,18 @@ func Return(
 	r *http.Request,
 	data interface{},
 	err error,
+) {
+	ReturnWithStatus(logger, w, r, http.StatusOK, data, err)
+}
+
+// ReturnWithStatus works like Return but writes statusCode for a successful response.
+func ReturnWithStatus(
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request,
+	statusCode int,
+	data interface{},
+	err error,
 ) {
 	if err != nil {
 		errResp := ErrorResponse{
@@ -57,7 +79,7 @@ func Return(
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(statusCode)
 
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
 		logger.Error("views.Return", zap.Error(err))
@@ -75,10 +97,16 @@ func defineStatusCode(err error) int {
 	case errors.Is(err, errs.ErrExecutionQuery) || errors.Is(err, errs.ErrMinio):
 		return http.StatusInternalServerError
 	case errors.Is(err, errs.ErrTypeMustBeNumeric) || errors.Is(err, errs.ErrDecodingJSON) ||
-		errors.Is(err, errs.ErrTypeMustBeUUID):
+		errors.Is(err, errs.ErrTypeMustBeUUID) || errors.Is(err, errs.ErrInvalidAudio) ||
+		errors.Is(err, errs.ErrInvalidArgument):
 		return http.StatusBadRequest
 	case errors.Is(err, errs.ErrNotFound):
 		return http.StatusNotFound
+	case errors.Is(err, errs.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, errs.ErrAlreadyExists) || errors.Is(err, errs.ErrSessionCompleted) ||
+		errors.Is(err, errs.ErrReviewConflict) || errors.Is(err, errs.ErrAnswerReplaced):
+		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
 	}
@@ -91,10 +119,22 @@ func defineErrorCode(err error) int {
 	case errors.Is(err, errs.ErrMinio):
 		return codeMinio
 	case errors.Is(err, errs.ErrTypeMustBeNumeric) || errors.Is(err, errs.ErrDecodingJSON) ||
-		errors.Is(err, errs.ErrTypeMustBeUUID):
+		errors.Is(err, errs.ErrTypeMustBeUUID) || errors.Is(err, errs.ErrInvalidArgument):
 		return codeTypeMustBeNumeric
+	case errors.Is(err, errs.ErrInvalidAudio):
+		return codeInvalidAudio
 	case errors.Is(err, errs.ErrNotFound):
 		return codeNotFound
+	case errors.Is(err, errs.ErrUnauthorized):
+		return codeUnauthorized
+	case errors.Is(err, errs.ErrAlreadyExists):
+		return codeAlreadyExists
+	case errors.Is(err, errs.ErrSessionCompleted):
+		return codeSessionCompleted
+	case errors.Is(err, errs.ErrReviewConflict):
+		return codeReviewConflict
+	case errors.Is(err, errs.ErrAnswerReplaced):
+		return codeAnswerReplaced
 	default:
 		return codeUnknown
 	}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 const (
@@ -26,6 +28,76 @@ const (
 	minioUseSSL        = "MINIO_USE_SSL"
 	minioImagesBucket  = "MINIO_IMAGES_BUCKET"
 	minioAnswersBucket = "MINIO_ANSWERS_BUCKET"
+
+	authSecret     = "AUTH_SECRET"
+	authTokenTTL   = "AUTH_TOKEN_TTL"
+	authAdminToken = "ADMIN_TOKEN"
+
+	defaultAuthTokenTTL = 7 * 24 * time.Hour
+
+	jobsWorkers      = "JOBS_WORKERS"
+	jobsMaxAttempts  = "JOBS_MAX_ATTEMPTS"
+	jobsPollInterval = "JOBS_POLL_INTERVAL"
+	jobsRetryBackoff = "JOBS_RETRY_BACKOFF"
+	jobsLease        = "JOBS_LEASE"
+
+	defaultJobsWorkers      = 2
+	defaultJobsMaxAttempts  = 5
+	defaultJobsPollInterval = time.Second
+	defaultJobsRetryBackoff = 10 * time.Second
+	defaultJobsLease        = 10 * time.Minute
+
+	transcriptionConcurrency = "TRANSCRIPTION_CONCURRENCY"
+
+	defaultTranscriptionConcurrency = 3
+
+	sttProvider       = "STT_PROVIDER"
+	whisperBinary     = "WHISPER_BINARY"
+	whisperModel      = "WHISPER_MODEL"
+	whisperLanguage   = "WHISPER_LANGUAGE"
+	sttFakeTranscript = "STT_FAKE_TRANSCRIPT"
+	deepgramUpload    = "DEEPGRAM_UPLOAD_MODE"
+
+	defaultSTTProvider     = "deepgram"
+	defaultWhisperLanguage = "en"
+	defaultDeepgramUpload  = "stream"
+
+	answerMaxSizeMB   = "ANSWER_MAX_SIZE_MB"
+	answerMaxDuration = "ANSWER_MAX_DURATION"
+	answerStreamIdle  = "ANSWER_STREAM_IDLE_TIMEOUT"
+	ffmpegBinary      = "FFMPEG_BINARY"
+
+	defaultAnswerMaxSizeMB   = 25
+	defaultAnswerMaxDuration = 5 * time.Minute
+	defaultAnswerStreamIdle  = 15 * time.Second
+
+	llmProvider     = "LLM_PROVIDER"
+	llmModel        = "LLM_MODEL"
+	llmTemperature  = "LLM_TEMPERATURE"
+	llmMaxTokens    = "LLM_MAX_TOKENS"
+	llmFakeScript   = "LLM_FAKE_SCRIPT"
+	llmRetries      = "LLM_VALIDATION_RETRIES"
+	openAICompatKey = "OPENAI_API_KEY"
+	openAICompatURL = "OPENAI_URL"
+
+	defaultLLMProvider    = "gemini"
+	defaultLLMTemperature = 0.2
+	defaultLLMMaxTokens   = 2048
+	defaultLLMRetries     = 2
+
+	promptsDir = "PROMPTS_DIR"
+
+	defaultPromptsDir = "prompts"
+
+	reviewMasteryInterval = "REVIEW_MASTERY_INTERVAL_DAYS"
+
+	defaultReviewMasteryInterval = 21
+
+	suggestionsTTL   = "SUGGESTIONS_TTL"
+	suggestionsCount = "SUGGESTIONS_COUNT"
+
+	defaultSuggestionsTTL   = 24 * time.Hour
+	defaultSuggestionsCount = 5
 )
 
 type Config struct {
@@ -35,6 +107,17 @@ type Config struct {
 	Deepgram *ExternalAPI
 	Gemini   *ExternalAPI
 	Minio    *Minio
+	Auth     *Auth
+	Jobs     *Jobs
+	STT      *STT
+	LLM      *LLM
+	Answers  *Answers
+	Prompts  *Prompts
+	Reviews  *Reviews
+
+	Suggestions *Suggestions
+
+	TranscriptionConcurrency int
 }
 
 func New() Config {
@@ -69,16 +152,134 @@ func New() Config {
 		AnswersBucket: os.Getenv(minioAnswersBucket),
 	}
 
+	Auth := Auth{
+		Secret:   os.Getenv(authSecret),
+		TokenTTL: getDuration(authTokenTTL, defaultAuthTokenTTL),
+
+		AdminToken: os.Getenv(authAdminToken),
+	}
+
+	Jobs := Jobs{
+		Workers:      getInt(jobsWorkers, defaultJobsWorkers),
+		MaxAttempts:  getInt(jobsMaxAttempts, defaultJobsMaxAttempts),
+		PollInterval: getDuration(jobsPollInterval, defaultJobsPollInterval),
+		RetryBackoff: getDuration(jobsRetryBackoff, defaultJobsRetryBackoff),
+		Lease:        getDuration(jobsLease, defaultJobsLease),
+	}
+
+	STT := STT{
+		Provider:        getString(sttProvider, defaultSTTProvider),
+		WhisperBinary:   os.Getenv(whisperBinary),
+		WhisperModel:    os.Getenv(whisperModel),
+		WhisperLanguage: getString(whisperLanguage, defaultWhisperLanguage),
+		FakeTranscript:  os.Getenv(sttFakeTranscript),
+		DeepgramUpload:  getString(deepgramUpload, defaultDeepgramUpload),
+	}
+
+	LLM := LLM{
+		Provider:    getString(llmProvider, defaultLLMProvider),
+		Model:       os.Getenv(llmModel),
+		Temperature: getFloat(llmTemperature, defaultLLMTemperature),
+		MaxTokens:   getInt(llmMaxTokens, defaultLLMMaxTokens),
+		Retries:     getCount(llmRetries, defaultLLMRetries),
+		OpenAI: &ExternalAPI{
+			APIKey: os.Getenv(openAICompatKey),
+			URL:    os.Getenv(openAICompatURL),
+		},
+		FakeScript: os.Getenv(llmFakeScript),
+	}
+
+	Answers := Answers{
+		MaxSize:     int64(getInt(answerMaxSizeMB, defaultAnswerMaxSizeMB)) << 20,
+		MaxDuration: getDuration(answerMaxDuration, defaultAnswerMaxDuration),
+		StreamIdle:  getDuration(answerStreamIdle, defaultAnswerStreamIdle),
+
+		FFmpegBinary: os.Getenv(ffmpegBinary),
+	}
+
+	Prompts := Prompts{
+		Dir: getString(promptsDir, defaultPromptsDir),
+	}
+
+	Reviews := Reviews{
+		MasteryIntervalDays: getInt(reviewMasteryInterval, defaultReviewMasteryInterval),
+	}
+
+	Suggestions := Suggestions{
+		TTL:   getDuration(suggestionsTTL, defaultSuggestionsTTL),
+		Count: getInt(suggestionsCount, defaultSuggestionsCount),
+	}
+
 	return Config{
 		HTTPPort: HTTPPort,
 
+		TranscriptionConcurrency: getInt(transcriptionConcurrency, defaultTranscriptionConcurrency),
+
 		Deepgram: &Deepgram,
 		Gemini:   &Gemini,
 		Postgres: &Postgres,
 		Minio:    &Minio,
+		Auth:     &Auth,
+		Jobs:     &Jobs,
+		STT:      &STT,
+		LLM:      &LLM,
+		Answers:  &Answers,
+		Prompts:  &Prompts,
+		Reviews:  &Reviews,
+
+		Suggestions: &Suggestions,
 	}
 }
 
+// getFloat reads a non-negative float, falling back to def when unset or invalid.
+func getFloat(key string, def float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil || value < 0 {
+		return def
+	}
+
+	return value
+}
+
+// getString reads a value, falling back to def when unset.
+func getString(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return def
+}
+
+// getInt reads a positive integer, falling back to def when unset or invalid.
+func getInt(key string, def int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return def
+	}
+
+	return value
+}
+
+// getCount reads a non-negative integer, so that zero can switch a feature off, falling back to def when unset or invalid.
+func getCount(key string, def int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return def
+	}
+
+	return value
+}
+
+// getDuration reads a time.ParseDuration-compatible value, falling back to def when unset or invalid.
+func getDuration(key string, def time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return def
+	}
+
+	return value
+}
+
 type ExternalAPI struct {
 	APIKey string
 	URL    string
@@ -94,6 +295,57 @@ type Minio struct {
 	AnswersBucket string
 }
 
+type Auth struct {
+	Secret   string
+	TokenTTL time.Duration
+
+	// AdminToken guards the admin endpoints; empty disables them
+	AdminToken string
+}
+
+type Jobs struct {
+	Workers      int
+	MaxAttempts  int
+	PollInterval time.Duration
+	// RetryBackoff is the delay before the first retry, doubled on every next attempt.
+	RetryBackoff time.Duration
+	// Lease is how long a worker owns a running job before another worker may pick it up.
+	Lease time.Duration
+}
+
+// STT selects the speech-to-text provider: deepgram, whisper_local or fake.
+type STT struct {
+	Provider string
+
+	// whisper.cpp-compatible binary and model for the whisper_local provider
+	WhisperBinary   string
+	WhisperModel    string
+	WhisperLanguage string
+
+	// FakeTranscript is returned by the fake provider; empty means a transcript derived from the object name
+	FakeTranscript string
+
+	// DeepgramUpload is how audio reaches Deepgram: stream posts the object bytes, url sends a presigned link
+	// and needs the answers bucket to be reachable from Deepgram
+	DeepgramUpload string
+}
+
+// LLM selects the text analyzer: gemini, openai (any OpenAI-compatible server, e.g. Ollama) or fake.
+type LLM struct {
+	Provider string
+	// Model overrides the provider default model
+	Model       string
+	Temperature float64
+	MaxTokens   int
+	// Retries is how many times an invalid reply is sent back to the model with the validation errors; 0 disables re-prompting
+	Retries int
+
+	OpenAI *ExternalAPI
+
+	// FakeScript is a JSON file with an array of replies returned by the fake provider in turn
+	FakeScript string
+}
+
 type DB struct {
 	URL      string
 	Host     string
@@ -117,3 +369,33 @@ func (db *DB) GetConnStr() string {
 		" dbname=" + db.DBName +
 		" sslmode=require"
 }
+
+// Answers limits uploaded answer recordings.
+type Answers struct {
+	// MaxSize is in bytes
+	MaxSize     int64
+	MaxDuration time.Duration
+	// StreamIdle is how long a streaming answer may go without a message before the connection is closed
+	StreamIdle time.Duration
+
+	// FFmpegBinary enables normalization of uploaded answers; empty disables it. Required by whisper_local
+	FFmpegBinary string
+}
+
+// Prompts locates the LLM prompt templates, stored as {Dir}/{name}/v{N}.tmpl.
+type Prompts struct {
+	Dir string
+}
+
+type Reviews struct {
+	// MasteryIntervalDays is the review interval from which a word counts as learned
+	MasteryIntervalDays int
+}
+
+// Suggestions configures the AI word suggestions of collections.
+type Suggestions struct {
+	// TTL is how long the cached suggestions are shown before they are generated again
+	TTL time.Duration
+	// Count is how many words are requested from the model
+	Count int
+}
@@ -5,82 +5,148 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 
 	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/entity"
 	"speech-processing-service/internal/errs"
 )
 
+const (
+	UploadStream = "stream"
+	UploadURL    = "url"
+
+	defaultAudioContentType = "audio/*"
+)
+
+type AudioReader interface {
+	GetAnswer(ctx context.Context, filename string) (io.ReadCloser, string, error)
+}
+
 type Deepgram struct {
 	client *http.Client
 	cfg    *config.ExternalAPI
+	upload string
+	reader AudioReader
 }
 
-func New(cfg *config.ExternalAPI) Deepgram {
+func New(cfg *config.ExternalAPI, stt *config.STT, reader AudioReader) (Deepgram, error) {
+	if stt.DeepgramUpload != UploadStream && stt.DeepgramUpload != UploadURL {
+		return Deepgram{}, errs.New(errs.ErrInitialization, "deepgram: unknown upload mode: "+stt.DeepgramUpload)
+	}
+
 	return Deepgram{
 		client: &http.Client{},
 		cfg:    cfg,
-	}
+		upload: stt.DeepgramUpload,
+		reader: reader,
+	}, nil
 }
 
 func (deepgram *Deepgram) GetTranscriptionURL() string {
-	return deepgram.cfg.URL + "listen?model=nova-3&smart_format=true"
+	return deepgram.cfg.URL + "listen?model=nova-3&smart_format=true&filler_words=true"
 }
 
-func (deepgram *Deepgram) GetTranscriptionHeaders() map[string]string {
+func (deepgram *Deepgram) GetTranscriptionHeaders(contentType string) map[string]string {
 	headers := map[string]string{
-		"Content-Type":  "application/json",
+		"Content-Type":  contentType,
 		"Authorization": "Token " + deepgram.cfg.APIKey,
 	}
 	return headers
 }
 
-func (deepgram *Deepgram) TranscribeAudio(ctx context.Context, url string) (string, error) {
+// requestBody returns the audio itself in stream mode, so the answers bucket may stay private,
+// or a JSON body with the presigned URL in url mode.
+func (deepgram *Deepgram) requestBody(ctx context.Context, audio entity.AudioSource) (io.ReadCloser, string, error) {
+	if deepgram.upload == UploadStream {
+		object, contentType, err := deepgram.reader.GetAnswer(ctx, audio.ObjectName)
+		if err != nil {
+			return nil, "", errs.Wrap("deepgram.reader.GetAnswer", err)
+		}
+
+		// Deepgram сам определяет формат, если тип не сохранен при загрузке
+		if contentType == "" || contentType == "application/octet-stream" {
+			contentType = defaultAudioContentType
+		}
+
+		return object, contentType, nil
+	}
+
 	reqBody := TranscribeTextReq{
-		URL: url,
+		URL: audio.URL,
 	}
 
 	var buf bytes.Buffer
 	encoder := json.NewEncoder(&buf)
 	encoder.SetEscapeHTML(false)
 	if err := encoder.Encode(reqBody); err != nil {
-		return "", errs.New(errs.ErrMarshalingJSON, err.Error())
+		return nil, "", errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	return io.NopCloser(&buf), "application/json", nil
+}
+
+func (deepgram *Deepgram) TranscribeAudio(ctx context.Context, audio entity.AudioSource) (entity.Transcription, error) {
+	body, contentType, err := deepgram.requestBody(ctx, audio)
+	if err != nil {
+		return entity.Transcription{}, err
 	}
 
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
 		deepgram.GetTranscriptionURL(),
-		&buf,
+		body,
 	)
 	if err != nil {
-		return "", errs.New(errs.ErrExecutionRequest, err.Error())
+		body.Close()
+
+		return entity.Transcription{}, errs.New(errs.ErrExecutionRequest, err.Error())
 	}
 
-	for key, value := range deepgram.GetTranscriptionHeaders() {
+	for key, value := range deepgram.GetTranscriptionHeaders(contentType) {
 		req.Header.Set(key, value)
 	}
 
 	resp, err := deepgram.client.Do(req)
 	if err != nil {
-		return "", errs.New(errs.ErrExecutionRequest, err.Error())
+		return entity.Transcription{}, errs.New(errs.ErrExecutionRequest, err.Error())
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", errs.New(errs.ErrUnexpectedStatusCode, fmt.Sprintf("status_code:%d", resp.StatusCode))
+		return entity.Transcription{}, errs.New(errs.ErrUnexpectedStatusCode, fmt.Sprintf("status_code:%d", resp.StatusCode))
 	}
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", errs.New(errs.ErrDecodingJSON, err.Error())
+		return entity.Transcription{}, errs.New(errs.ErrDecodingJSON, err.Error())
 	}
 
 	var transcriptionResp TranscribeTextResp
 	if err := json.Unmarshal(bodyBytes, &transcriptionResp); err != nil {
-		return "", errs.New(errs.ErrDecodingJSON, err.Error())
+		return entity.Transcription{}, errs.New(errs.ErrDecodingJSON, err.Error())
+	}
+
+	if len(transcriptionResp.Results.Channels) == 0 || len(transcriptionResp.Results.Channels[0].Alternatives) == 0 {
+		return entity.Transcription{}, errs.New(errs.ErrDecodingJSON, "deepgram: empty transcription")
+	}
+
+	alternative := transcriptionResp.Results.Channels[0].Alternatives[0]
+
+	words := make([]entity.TranscribedWord, 0, len(alternative.Words))
+	for _, word := range alternative.Words {
+		words = append(words, entity.TranscribedWord{
+			Word:       word.Word,
+			Start:      word.Start,
+			End:        word.End,
+			Confidence: word.Confidence,
+		})
 	}
 
-	return transcriptionResp.Results.Channels[0].Alternatives[0].Transcript, nil
+	return entity.Transcription{
+		Transcript: alternative.Transcript,
+		Words:      words,
+	}, nil
 }
@@ -13,9 +13,26 @@ type Channels struct {
 }
 
 type Alternatives struct {
-	Transcript string `json:"transcript"`
+	Transcript string  `json:"transcript"`
+	Confidence float64 `json:"confidence"`
+	Words      []Word  `json:"words"`
+}
+
+type Word struct {
+	Word           string  `json:"word"`
+	PunctuatedWord string  `json:"punctuated_word"`
+	Start          float64 `json:"start"`
+	End            float64 `json:"end"`
+	Confidence     float64 `json:"confidence"`
 }
 
 type TranscribeTextResp struct {
 	Results Results `json:"results"`
 }
+
+// LiveMessage is a message of the live API; only Results messages carry a transcript.
+type LiveMessage struct {
+	Type    string   `json:"type"`
+	IsFinal bool     `json:"is_final"`
+	Channel Channels `json:"channel"`
+}
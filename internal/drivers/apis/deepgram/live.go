@@ -0,0 +1,117 @@
+package deepgram
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	resultsMessage = "Results"
+	closeStream    = `{"type":"CloseStream"}`
+)
+
+// LiveStream relays audio chunks to the Deepgram live API.
+type LiveStream struct {
+	conn      *websocket.Conn
+	closeOnce sync.Once
+}
+
+func (deepgram *Deepgram) GetLiveURL() string {
+	// Live API доступен по тому же адресу, но по протоколу WebSocket
+	url := strings.Replace(deepgram.cfg.URL, "https://", "wss://", 1)
+	url = strings.Replace(url, "http://", "ws://", 1)
+
+	return url + "listen?model=nova-3&smart_format=true&filler_words=true&interim_results=true"
+}
+
+func (deepgram *Deepgram) OpenStream(ctx context.Context) (*LiveStream, error) {
+	config, err := websocket.NewConfig(deepgram.GetLiveURL(), deepgram.cfg.URL)
+	if err != nil {
+		return nil, errs.New(errs.ErrExecutionRequest, "websocket.NewConfig: "+err.Error())
+	}
+
+	config.Header.Set("Authorization", "Token "+deepgram.cfg.APIKey)
+
+	conn, err := config.DialContext(ctx)
+	if err != nil {
+		return nil, errs.New(errs.ErrExecutionRequest, "config.DialContext: "+err.Error())
+	}
+
+	return &LiveStream{
+		conn: conn,
+	}, nil
+}
+
+func (s *LiveStream) Send(chunk []byte) error {
+	if err := websocket.Message.Send(s.conn, chunk); err != nil {
+		return errs.New(errs.ErrExecutionRequest, "websocket.Message.Send: "+err.Error())
+	}
+
+	return nil
+}
+
+// CloseSend asks Deepgram to flush the remaining results and close the connection.
+func (s *LiveStream) CloseSend() error {
+	if err := websocket.Message.Send(s.conn, closeStream); err != nil {
+		return errs.New(errs.ErrExecutionRequest, "websocket.Message.Send: "+err.Error())
+	}
+
+	return nil
+}
+
+// Recv returns the next recognition result and io.EOF once Deepgram has closed the stream.
+func (s *LiveStream) Recv() (entity.TranscriptUpdate, error) {
+	for {
+		var message LiveMessage
+		if err := websocket.JSON.Receive(s.conn, &message); err != nil {
+			if err == io.EOF {
+				return entity.TranscriptUpdate{}, io.EOF
+			}
+
+			return entity.TranscriptUpdate{}, errs.New(errs.ErrExecutionRequest, "websocket.JSON.Receive: "+err.Error())
+		}
+
+		// Metadata и служебные сообщения не содержат текста
+		if message.Type != resultsMessage || len(message.Channel.Alternatives) == 0 {
+			continue
+		}
+
+		alternative := message.Channel.Alternatives[0]
+
+		words := make([]entity.TranscribedWord, 0, len(alternative.Words))
+		for _, word := range alternative.Words {
+			words = append(words, entity.TranscribedWord{
+				Word:       word.Word,
+				Start:      word.Start,
+				End:        word.End,
+				Confidence: word.Confidence,
+			})
+		}
+
+		return entity.TranscriptUpdate{
+			Transcript: alternative.Transcript,
+			Words:      words,
+			IsFinal:    message.IsFinal,
+		}, nil
+	}
+}
+
+func (s *LiveStream) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		err = s.conn.Close()
+	})
+
+	if err != nil {
+		return errs.New(errs.ErrExecutionRequest, "s.conn.Close: "+err.Error())
+	}
+
+	return nil
+}
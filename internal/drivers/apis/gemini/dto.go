@@ -8,8 +8,26 @@ type Contents struct {
 	Parts []Part `json:"parts"`
 }
 
+type GenerationConfig struct {
+	Temperature      float64 `json:"temperature"`
+	MaxOutputTokens  int     `json:"maxOutputTokens,omitempty"`
+	ResponseMimeType string  `json:"responseMimeType,omitempty"`
+	ResponseSchema   *Schema `json:"responseSchema,omitempty"`
+}
+
+type Schema struct {
+	Type             string             `json:"type"`
+	Description      string             `json:"description,omitempty"`
+	Enum             []string           `json:"enum,omitempty"`
+	Items            *Schema            `json:"items,omitempty"`
+	Properties       map[string]*Schema `json:"properties,omitempty"`
+	Required         []string           `json:"required,omitempty"`
+	PropertyOrdering []string           `json:"propertyOrdering,omitempty"`
+}
+
 type AnalyzeTextReq struct {
-	Contents []Contents `json:"contents"`
+	Contents         []Contents       `json:"contents"`
+	GenerationConfig GenerationConfig `json:"generationConfig"`
 }
 
 type AnalyzeTextResp struct {
@@ -7,28 +7,45 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"speech-processing-service/internal/config"
 	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/jsonschema"
+)
+
+const (
+	defaultModel = "gemini-2.0-flash"
+
+	jsonMimeType = "application/json"
 )
 
 type Gemini struct {
 	client *http.Client
 	cfg    *config.ExternalAPI
+	llm    *config.LLM
 }
 
-func New(cfg *config.ExternalAPI) Gemini {
+func New(cfg *config.ExternalAPI, llm *config.LLM) Gemini {
 	return Gemini{
 		client: &http.Client{},
 		cfg:    cfg,
+		llm:    llm,
 	}
 }
 
 func (g *Gemini) GetTranscriptionURL() string {
-	return g.cfg.URL + "/models/gemini-2.0-flash:generateContent?key=" + g.cfg.APIKey
+	model := g.llm.Model
+	if model == "" {
+		model = defaultModel
+	}
+
+	return g.cfg.URL + "/models/" + model + ":generateContent?key=" + g.cfg.APIKey
 }
 
-func (g *Gemini) AnalyzeText(ctx context.Context, prompt string) (string, error) {
+// AnalyzeText sends the prompt to Gemini. With a non-nil schema the JSON output mode is used,
+// so the reply is a JSON document matching the schema.
+func (g *Gemini) AnalyzeText(ctx context.Context, prompt string, schema *jsonschema.Schema) (string, error) {
 	reqBody := AnalyzeTextReq{
 		Contents: []Contents{
 			{
@@ -39,6 +56,15 @@ func (g *Gemini) AnalyzeText(ctx context.Context, prompt string) (string, error)
 				},
 			},
 		},
+		GenerationConfig: GenerationConfig{
+			Temperature:     g.llm.Temperature,
+			MaxOutputTokens: g.llm.MaxTokens,
+		},
+	}
+
+	if schema != nil {
+		reqBody.GenerationConfig.ResponseMimeType = jsonMimeType
+		reqBody.GenerationConfig.ResponseSchema = toSchema(schema)
 	}
 
 	var buf bytes.Buffer
@@ -54,35 +80,58 @@ func (g *Gemini) AnalyzeText(ctx context.Context, prompt string) (string, error)
 		&buf,
 	)
 	if err != nil {
-		return "", err
+		return "", errs.New(errs.ErrExecutionRequest, err.Error())
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := g.client.Do(req)
 	if err != nil {
-		return "", err
+		return "", errs.New(errs.ErrExecutionRequest, err.Error())
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := ioutil.ReadAll(resp.Body)
-		fmt.Println("Response body:", string(bodyBytes))
-		return "", fmt.Errorf("failed to analyze text: %s", resp.Status)
-	}
-
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return "", errs.New(errs.ErrDecodingJSON, err.Error())
 	}
 
+	if resp.StatusCode != http.StatusOK {
+		return "", errs.New(errs.ErrUnexpectedStatusCode, fmt.Sprintf("status_code:%d body:%s", resp.StatusCode, bodyBytes))
+	}
+
 	var result AnalyzeTextResp
 	if err := json.Unmarshal(bodyBytes, &result); err != nil {
-		return "", err
+		return "", errs.New(errs.ErrDecodingJSON, err.Error())
 	}
 
 	if len(result.Candidates) > 0 && len(result.Candidates[0].Content.Parts) > 0 {
 		return result.Candidates[0].Content.Parts[0].Text, nil
 	}
 
-	return "", fmt.Errorf("no text found in response")
+	return "", errs.New(errs.ErrDecodingJSON, "no text found in response")
+}
+
+// toSchema converts a JSON schema to the Gemini OpenAPI dialect with upper-case type names.
+func toSchema(schema *jsonschema.Schema) *Schema {
+	if schema == nil {
+		return nil
+	}
+
+	result := &Schema{
+		Type:             strings.ToUpper(schema.Type),
+		Description:      schema.Description,
+		Enum:             schema.Enum,
+		Items:            toSchema(schema.Items),
+		Required:         schema.Required,
+		PropertyOrdering: schema.PropertyOrdering,
+	}
+
+	if len(schema.Properties) > 0 {
+		result.Properties = make(map[string]*Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			result.Properties[name] = toSchema(property)
+		}
+	}
+
+	return result
 }
@@ -0,0 +1,120 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/jsonschema"
+)
+
+const (
+	defaultFeedback = "This is a scripted analysis produced by the fake analyzer."
+)
+
+// answerID matches the answer headers of the analysis prompt, e.g. "Answer ID: 12".
+var answerID = regexp.MustCompile(`(?m)^Answer ID: ([0-9]+)$`)
+
+// suggestionsCount matches the size of the word suggestions prompt, e.g. "Number of suggestions: 5".
+var suggestionsCount = regexp.MustCompile(`(?m)^Number of suggestions: ([0-9]+)$`)
+
+// Fake replays scripted replies in order, repeating the last one when the script runs out.
+// Without a script it replies with a valid analysis of the answers listed in the prompt,
+// or with word suggestions to the word suggestions prompt.
+type Fake struct {
+	mu      sync.Mutex
+	replies []string
+	next    int
+}
+
+func New(cfg *config.LLM) (*Fake, error) {
+	var replies []string
+
+	if cfg.FakeScript != "" {
+		data, err := os.ReadFile(cfg.FakeScript)
+		if err != nil {
+			return nil, errs.New(errs.ErrInitialization, "fake llm script: "+err.Error())
+		}
+
+		if err := json.Unmarshal(data, &replies); err != nil || len(replies) == 0 {
+			return nil, errs.New(errs.ErrInitialization, "fake llm script must be a non-empty JSON array of strings")
+		}
+	}
+
+	return &Fake{
+		replies: replies,
+	}, nil
+}
+
+func (f *Fake) AnalyzeText(_ context.Context, prompt string, _ *jsonschema.Schema) (string, error) {
+	if f.replies == nil {
+		return defaultReply(prompt)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reply := f.replies[min(f.next, len(f.replies)-1)]
+	f.next++
+
+	return reply, nil
+}
+
+func defaultReply(prompt string) (string, error) {
+	if match := suggestionsCount.FindStringSubmatch(prompt); match != nil {
+		return suggestionsReply(match[1])
+	}
+
+	answers := []entity.AnswerFeedback{}
+	for _, match := range answerID.FindAllStringSubmatch(prompt, -1) {
+		id, _ := strconv.Atoi(match[1])
+
+		answers = append(answers, entity.AnswerFeedback{
+			AnswerID:      id,
+			Relevance:     entity.RelevanceRelevant,
+			Level:         "B1",
+			GrammarIssues: []entity.GrammarIssue{},
+			Feedback:      defaultFeedback,
+		})
+	}
+
+	reply, err := json.Marshal(entity.AnalyzeTextResult{
+		OverallLevel:        "B1",
+		TopWords:            []entity.TopWord{{Words: "experience", Level: "B1"}},
+		RephraseSuggestions: []entity.RephraseSuggestion{},
+		OverallFeedback:     defaultFeedback,
+		Answers:             answers,
+	})
+	if err != nil {
+		return "", errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	return string(reply), nil
+}
+
+func suggestionsReply(count string) (string, error) {
+	n, _ := strconv.Atoi(count)
+
+	suggestions := make([]entity.AISuggestion, 0, n)
+	for i := 1; i <= n; i++ {
+		suggestions = append(suggestions, entity.AISuggestion{
+			Word:        fmt.Sprintf("word%d", i),
+			Translation: fmt.Sprintf("слово%d", i),
+			Reason:      "This is a scripted suggestion produced by the fake analyzer.",
+		})
+	}
+
+	reply, err := json.Marshal(entity.WordSuggestionsResult{Suggestions: suggestions})
+	if err != nil {
+		return "", errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	return string(reply), nil
+}
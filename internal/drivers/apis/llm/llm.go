@@ -0,0 +1,49 @@
+package llm
+
+import (
+	"context"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/drivers/apis/gemini"
+	"speech-processing-service/internal/drivers/apis/llm/fake"
+	"speech-processing-service/internal/drivers/apis/openai"
+	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/jsonschema"
+)
+
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+	ProviderFake   = "fake"
+)
+
+// Analyzer completes a prompt. A non-nil schema asks the model for JSON matching it.
+type Analyzer interface {
+	AnalyzeText(ctx context.Context, prompt string, schema *jsonschema.Schema) (string, error)
+}
+
+// New returns the text analyzer selected by cfg.LLM.Provider.
+func New(cfg *config.Config) (Analyzer, error) {
+	switch cfg.LLM.Provider {
+	case ProviderGemini:
+		provider := gemini.New(cfg.Gemini, cfg.LLM)
+
+		return &provider, nil
+	case ProviderOpenAI:
+		provider, err := openai.New(cfg.LLM.OpenAI, cfg.LLM)
+		if err != nil {
+			return nil, errs.Wrap("openai.New", err)
+		}
+
+		return &provider, nil
+	case ProviderFake:
+		provider, err := fake.New(cfg.LLM)
+		if err != nil {
+			return nil, errs.Wrap("fake.New", err)
+		}
+
+		return provider, nil
+	default:
+		return nil, errs.New(errs.ErrInitialization, "unknown llm provider: "+cfg.LLM.Provider)
+	}
+}
@@ -0,0 +1,34 @@
+package openai
+
+import "speech-processing-service/internal/jsonschema"
+
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type JSONSchema struct {
+	Name   string             `json:"name"`
+	Schema *jsonschema.Schema `json:"schema"`
+}
+
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+type ChatCompletionReq struct {
+	Model          string          `json:"model"`
+	Messages       []Message       `json:"messages"`
+	Temperature    float64         `json:"temperature"`
+	MaxTokens      int             `json:"max_tokens,omitempty"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+type Choice struct {
+	Message Message `json:"message"`
+}
+
+type ChatCompletionResp struct {
+	Choices []Choice `json:"choices"`
+}
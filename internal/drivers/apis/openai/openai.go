@@ -0,0 +1,117 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/jsonschema"
+)
+
+const (
+	responseSchemaName = "response"
+)
+
+// OpenAI talks to any server implementing the OpenAI /v1/chat/completions API:
+// OpenAI itself, Ollama, llama.cpp server, vLLM and others.
+type OpenAI struct {
+	client *http.Client
+	cfg    *config.ExternalAPI
+	llm    *config.LLM
+}
+
+func New(cfg *config.ExternalAPI, llm *config.LLM) (OpenAI, error) {
+	if cfg.URL == "" || llm.Model == "" {
+		return OpenAI{}, errs.New(errs.ErrInitialization, "openai: url and model are required")
+	}
+
+	return OpenAI{
+		client: &http.Client{},
+		cfg:    cfg,
+		llm:    llm,
+	}, nil
+}
+
+// GetCompletionsURL accepts base URLs with or without the /v1 suffix.
+func (o *OpenAI) GetCompletionsURL() string {
+	base := strings.TrimSuffix(o.cfg.URL, "/")
+	if !strings.HasSuffix(base, "/v1") {
+		base += "/v1"
+	}
+
+	return base + "/chat/completions"
+}
+
+// AnalyzeText sends the prompt as a single user message. With a non-nil schema the
+// json_schema response format is requested.
+func (o *OpenAI) AnalyzeText(ctx context.Context, prompt string, schema *jsonschema.Schema) (string, error) {
+	reqBody := ChatCompletionReq{
+		Model: o.llm.Model,
+		Messages: []Message{
+			{
+				Role:    "user",
+				Content: prompt,
+			},
+		},
+		Temperature: o.llm.Temperature,
+		MaxTokens:   o.llm.MaxTokens,
+	}
+
+	if schema != nil {
+		reqBody.ResponseFormat = &ResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &JSONSchema{
+				Name:   responseSchemaName,
+				Schema: schema,
+			},
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(reqBody); err != nil {
+		return "", errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.GetCompletionsURL(), &buf)
+	if err != nil {
+		return "", errs.New(errs.ErrExecutionRequest, err.Error())
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	// Локальные серверы обычно работают без ключа
+	if o.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.cfg.APIKey)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", errs.New(errs.ErrExecutionRequest, err.Error())
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errs.New(errs.ErrDecodingJSON, err.Error())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errs.New(errs.ErrUnexpectedStatusCode, fmt.Sprintf("status_code:%d body:%s", resp.StatusCode, bodyBytes))
+	}
+
+	var result ChatCompletionResp
+	if err := json.Unmarshal(bodyBytes, &result); err != nil {
+		return "", errs.New(errs.ErrDecodingJSON, err.Error())
+	}
+
+	if len(result.Choices) == 0 {
+		return "", errs.New(errs.ErrDecodingJSON, "no choices in response")
+	}
+
+	return result.Choices[0].Message.Content, nil
+}
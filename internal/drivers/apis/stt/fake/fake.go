@@ -0,0 +1,148 @@
+package fake
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+const (
+	wordSeconds       = 0.4
+	gapSeconds        = 0.1
+	defaultConfidence = 0.95
+
+	streamBuffer = 64
+)
+
+// Fake is a deterministic transcriber for tests and demos without network access.
+type Fake struct {
+	transcript string
+}
+
+func New(cfg *config.STT) Fake {
+	return Fake{
+		transcript: cfg.FakeTranscript,
+	}
+}
+
+func (f *Fake) TranscribeAudio(_ context.Context, audio entity.AudioSource) (entity.Transcription, error) {
+	transcript := f.transcript
+	if transcript == "" {
+		name := strings.TrimSuffix(path.Base(audio.ObjectName), path.Ext(audio.ObjectName))
+		transcript = "This is a fake transcript of the answer " + name + "."
+	}
+
+	return entity.Transcription{
+		Transcript: transcript,
+		Words:      timings(transcript),
+	}, nil
+}
+
+// OpenStream reveals one more word of the transcript per received chunk and the whole transcript
+// as the final result.
+func (f *Fake) OpenStream(_ context.Context) (*Stream, error) {
+	transcript := f.transcript
+	if transcript == "" {
+		transcript = "This is a fake transcript of the streamed answer."
+	}
+
+	return &Stream{
+		words:   strings.Fields(transcript),
+		updates: make(chan entity.TranscriptUpdate, streamBuffer),
+	}, nil
+}
+
+// Stream is the streaming counterpart of Fake.
+type Stream struct {
+	mu      sync.Mutex
+	words   []string
+	chunks  int
+	closed  bool
+	updates chan entity.TranscriptUpdate
+}
+
+func (s *Stream) Send(_ []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errs.New(errs.ErrExecutionRequest, "fake stream is closed")
+	}
+
+	s.chunks++
+
+	interim := strings.Join(s.words[:min(s.chunks, len(s.words))], " ")
+
+	// Промежуточный результат можно пропустить, если читатель не успевает
+	select {
+	case s.updates <- entity.TranscriptUpdate{Transcript: interim, Words: timings(interim)}:
+	default:
+	}
+
+	return nil
+}
+
+func (s *Stream) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	transcript := strings.Join(s.words, " ")
+	s.updates <- entity.TranscriptUpdate{
+		Transcript: transcript,
+		Words:      timings(transcript),
+		IsFinal:    true,
+	}
+
+	s.closed = true
+	close(s.updates)
+
+	return nil
+}
+
+func (s *Stream) Recv() (entity.TranscriptUpdate, error) {
+	update, ok := <-s.updates
+	if !ok {
+		return entity.TranscriptUpdate{}, io.EOF
+	}
+
+	return update, nil
+}
+
+func (s *Stream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.updates)
+	}
+
+	return nil
+}
+
+// timings spreads the words evenly, so fluency metrics are predictable.
+func timings(transcript string) []entity.TranscribedWord {
+	fields := strings.Fields(transcript)
+	words := make([]entity.TranscribedWord, 0, len(fields))
+	for i, field := range fields {
+		start := float64(i) * (wordSeconds + gapSeconds)
+		words = append(words, entity.TranscribedWord{
+			Word:       strings.ToLower(strings.Trim(field, ".,!?;:\"")),
+			Start:      start,
+			End:        start + wordSeconds,
+			Confidence: defaultConfidence,
+		})
+	}
+
+	return words
+}
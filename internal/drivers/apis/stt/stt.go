@@ -0,0 +1,120 @@
+package stt
+
+import (
+	"context"
+	"io"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/drivers/apis/deepgram"
+	"speech-processing-service/internal/drivers/apis/stt/fake"
+	"speech-processing-service/internal/drivers/tools/whisper"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+const (
+	ProviderDeepgram     = "deepgram"
+	ProviderWhisperLocal = "whisper_local"
+	ProviderFake         = "fake"
+)
+
+type Transcriber interface {
+	TranscribeAudio(ctx context.Context, audio entity.AudioSource) (entity.Transcription, error)
+}
+
+// Stream is a live recognition session. Send and CloseSend are called by one goroutine and Recv by another.
+type Stream interface {
+	Send(chunk []byte) error
+	// CloseSend tells the provider that the audio is over; the remaining results are still returned by Recv
+	CloseSend() error
+	// Recv returns the next result and io.EOF after the last one
+	Recv() (entity.TranscriptUpdate, error)
+	Close() error
+}
+
+type StreamTranscriber interface {
+	OpenStream(ctx context.Context) (Stream, error)
+}
+
+type AudioReader interface {
+	GetAnswer(ctx context.Context, filename string) (io.ReadCloser, string, error)
+}
+
+// New returns the speech-to-text provider selected by cfg.STT.Provider.
+func New(cfg *config.Config, reader AudioReader) (Transcriber, error) {
+	switch cfg.STT.Provider {
+	case ProviderDeepgram:
+		provider, err := deepgram.New(cfg.Deepgram, cfg.STT, reader)
+		if err != nil {
+			return nil, errs.Wrap("deepgram.New", err)
+		}
+
+		return &provider, nil
+	case ProviderWhisperLocal:
+		// whisper.cpp читает только wav 16 кГц, в который записи переводит ffmpeg
+		if cfg.Answers.FFmpegBinary == "" {
+			return nil, errs.New(errs.ErrInitialization, "whisper_local stt provider requires FFMPEG_BINARY")
+		}
+
+		provider, err := whisper.New(cfg.STT, reader)
+		if err != nil {
+			return nil, errs.Wrap("whisper.New", err)
+		}
+
+		return &provider, nil
+	case ProviderFake:
+		provider := fake.New(cfg.STT)
+
+		return &provider, nil
+	default:
+		return nil, errs.New(errs.ErrInitialization, "unknown stt provider: "+cfg.STT.Provider)
+	}
+}
+
+// NewStreaming returns the streaming counterpart of the provider selected by cfg.STT.Provider, or nil
+// when the provider can only transcribe whole recordings.
+func NewStreaming(cfg *config.Config, reader AudioReader) (StreamTranscriber, error) {
+	switch cfg.STT.Provider {
+	case ProviderDeepgram:
+		provider, err := deepgram.New(cfg.Deepgram, cfg.STT, reader)
+		if err != nil {
+			return nil, errs.Wrap("deepgram.New", err)
+		}
+
+		return deepgramStreams{provider: &provider}, nil
+	case ProviderFake:
+		provider := fake.New(cfg.STT)
+
+		return fakeStreams{provider: &provider}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// Провайдеры возвращают свои типы потоков, адаптеры приводят их к Stream
+
+type deepgramStreams struct {
+	provider *deepgram.Deepgram
+}
+
+func (s deepgramStreams) OpenStream(ctx context.Context) (Stream, error) {
+	stream, err := s.provider.OpenStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+type fakeStreams struct {
+	provider *fake.Fake
+}
+
+func (s fakeStreams) OpenStream(ctx context.Context) (Stream, error) {
+	stream, err := s.provider.OpenStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
@@ -1,6 +1,10 @@
 package storage
 
-import "github.com/google/uuid"
+import (
+	"speech-processing-service/internal/entity"
+
+	"github.com/google/uuid"
+)
 
 type Topic struct {
 	ID          int    `db:"id"`
@@ -16,10 +20,43 @@ type Question struct {
 }
 
 type Answer struct {
-	ID         int    `db:"id"`
-	QuestionID int    `db:"question_id"`
-	SessionID  string `db:"session_id"`
-	Filename   string `db:"minio_filename"`
+	ID                 int     `db:"id"`
+	QuestionID         int     `db:"question_id"`
+	SessionID          string  `db:"session_id"`
+	Filename           string  `db:"minio_filename"`
+	NormalizedFilename *string `db:"normalized_filename"`
+	Transcript         *string `db:"transcript"`
+	Fluency            *string `db:"fluency"`
+}
+
+type Session struct {
+	ID          string  `db:"id"`
+	UserID      *int    `db:"user_id"`
+	TopicID     int     `db:"topic_id"`
+	TopicTitle  string  `db:"topic_title"`
+	Status      string  `db:"status"`
+	Analysis    *string `db:"analysis"`
+	CreatedAt   string  `db:"created_at"`
+	CompletedAt *string `db:"completed_at"`
+}
+
+type SessionSummary struct {
+	ID           string  `db:"id"`
+	TopicID      int     `db:"topic_id"`
+	TopicTitle   string  `db:"topic_title"`
+	Status       string  `db:"status"`
+	OverallLevel *string `db:"overall_level"`
+	AnswersCount int     `db:"answers_count"`
+	CreatedAt    string  `db:"created_at"`
+	CompletedAt  *string `db:"completed_at"`
+}
+
+type SessionAnswer struct {
+	ID           int     `db:"id"`
+	QuestionID   int     `db:"question_id"`
+	QuestionText string  `db:"question_text"`
+	Filename     string  `db:"minio_filename"`
+	Transcript   *string `db:"transcript"`
 }
 
 type Article struct {
@@ -71,6 +108,117 @@ type UserWord struct {
 	Example        *string `db:"example"`
 	NextReviewDate string  `db:"next_review_date"`
 	ReviewCount    int     `db:"review_count"`
+	EaseFactor     float64 `db:"ease_factor"`
+	IntervalDays   int     `db:"interval_days"`
+	Repetitions    int     `db:"repetitions"`
+	Lapses         int     `db:"lapses"`
+	LastReviewedAt *string `db:"last_reviewed_at"`
+	LearnedAt      *string `db:"learned_at"`
 	CreatedAt      string  `db:"created_at"`
 	UpdatedAt      string  `db:"updated_at"`
 }
+
+// Entity converts the stored word with its review schedule to the entity returned by the usecases.
+func (w UserWord) Entity() entity.UserWord {
+	return entity.UserWord{
+		ID:             w.ID,
+		CollectionID:   w.CollectionID,
+		Word:           w.Word,
+		Translation:    w.Translation,
+		Example:        w.Example,
+		NextReviewDate: w.NextReviewDate,
+		ReviewCount:    w.ReviewCount,
+		Schedule: entity.ReviewSchedule{
+			EaseFactor:   w.EaseFactor,
+			IntervalDays: w.IntervalDays,
+			Repetitions:  w.Repetitions,
+			Lapses:       w.Lapses,
+		},
+		LastReviewedAt: w.LastReviewedAt,
+		LearnedAt:      w.LearnedAt,
+		CreatedAt:      w.CreatedAt,
+		UpdatedAt:      w.UpdatedAt,
+	}
+}
+
+type User struct {
+	ID           int    `db:"id"`
+	Email        string `db:"email"`
+	PasswordHash string `db:"password_hash"`
+	CreatedAt    string `db:"created_at"`
+	UpdatedAt    string `db:"updated_at"`
+}
+
+type UserSettings struct {
+	FeedbackLanguage string `db:"feedback_language"`
+	TimeZone         string `db:"time_zone"`
+}
+
+type WordSuggestions struct {
+	Suggestions *string `db:"ai_suggestions"`
+	GeneratedAt *string `db:"ai_suggestions_generated_at"`
+	// Fresh is whether the suggestions are younger than the TTL
+	Fresh bool `db:"fresh"`
+}
+
+// reviewedWord is the state of a word locked for a review.
+type reviewedWord struct {
+	CollectionID string `db:"collection_id"`
+	Learned      bool   `db:"learned"`
+	// StudyDate is the current date in the time zone of the user, YYYY-MM-DD
+	StudyDate string `db:"study_date"`
+}
+
+type Job struct {
+	ID          string  `db:"id"`
+	Type        string  `db:"type"`
+	UserID      *int    `db:"user_id"`
+	Payload     []byte  `db:"payload"`
+	Status      string  `db:"status"`
+	Progress    int     `db:"progress"`
+	Result      []byte  `db:"result"`
+	Attempts    int     `db:"attempts"`
+	MaxAttempts int     `db:"max_attempts"`
+	LastError   *string `db:"last_error"`
+	RunAt       string  `db:"run_at"`
+	CreatedAt   string  `db:"created_at"`
+	UpdatedAt   string  `db:"updated_at"`
+	FinishedAt  *string `db:"finished_at"`
+}
+
+type SessionMetrics struct {
+	SessionID      string   `db:"session_id"`
+	OverallLevel   string   `db:"overall_level"`
+	AnswersCount   int      `db:"answers_count"`
+	GrammarIssues  int      `db:"grammar_issues"`
+	Vocabulary     string   `db:"vocabulary"`
+	WordsPerMinute *float64 `db:"words_per_minute"`
+	FillerRate     *float64 `db:"filler_rate"`
+	CompletedAt    string   `db:"completed_at"`
+}
+
+type GrammarMistakeStat struct {
+	Category   string `db:"category"`
+	Mistakes   int    `db:"mistakes"`
+	Sessions   int    `db:"sessions"`
+	LastSeenAt string `db:"last_seen_at"`
+}
+
+type GrammarMistake struct {
+	SessionID         string `db:"session_id"`
+	AnswerID          *int   `db:"answer_id"`
+	Category          string `db:"category"`
+	Sentence          string `db:"sentence"`
+	CorrectedSentence string `db:"corrected_sentence"`
+	Explanation       string `db:"explanation"`
+	CreatedAt         string `db:"created_at"`
+}
+
+type CategoryArticle struct {
+	Category      string `db:"category"`
+	ID            int    `db:"id"`
+	ImageURL      string `db:"image_url"`
+	Title         string `db:"title"`
+	Level         string `db:"level"`
+	MinutesToRead int    `db:"minutes_to_read"`
+}
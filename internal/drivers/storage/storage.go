@@ -2,8 +2,13 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
 
 	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/entity"
 	"speech-processing-service/internal/errs"
 
 	"github.com/google/uuid"
@@ -14,7 +19,8 @@ import (
 )
 
 const (
-	errCodeViolation = "23503"
+	errCodeViolation       = "23503"
+	errCodeUniqueViolation = "23505"
 )
 
 type Storage struct {
@@ -72,11 +78,12 @@ func (s *Storage) GetQuestionsByTopicID(ctx context.Context, topicID int) ([]Que
 	return questions, nil
 }
 
-func (s *Storage) CreateSession(ctx context.Context, sessionID string, topicID int) error {
+func (s *Storage) CreateSession(ctx context.Context, sessionID string, userID, topicID int) error {
 	_, err := s.db.ExecContext(
 		ctx,
-		"INSERT INTO sessions (id, topic_id) VALUES ($1, $2)",
+		"INSERT INTO sessions (id, user_id, topic_id) VALUES ($1, $2, $3)",
 		sessionID,
+		userID,
 		topicID,
 	)
 	if err != nil {
@@ -90,23 +97,136 @@ func (s *Storage) CreateSession(ctx context.Context, sessionID string, topicID i
 	return nil
 }
 
-func (s *Storage) CreateAnswer(ctx context.Context, sessionID string, questionID int, filename string) error {
-	_, err := s.db.ExecContext(
+// SaveAnswer stores the answer to the question, replacing the previous recording of the same question,
+// and returns the answer ID and the object names of the replaced recording. The session row is locked, so uploads
+// can't race with each other. The completion doesn't lock the session: a transcript of the replaced recording is
+// rejected by SaveAnswerTranscript instead.
+func (s *Storage) SaveAnswer(ctx context.Context, sessionID string, questionID int, file entity.AnswerFile) (int, []string, error) {
+	var durationMS *int64
+	if file.Duration != nil {
+		ms := file.Duration.Milliseconds()
+		durationMS = &ms
+	}
+
+	var (
+		normalizedFilename *string
+		speechDurationMS   *int64
+		loudness           *float64
+	)
+	if file.Normalized != nil {
+		ms := file.Normalized.Duration.Milliseconds()
+		normalizedFilename = &file.Normalized.ObjectName
+		speechDurationMS = &ms
+		loudness = file.Normalized.Loudness
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, nil, errs.New(errs.ErrExecutionQuery, "s.db.BeginTxx: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	var session Session
+	if err := tx.GetContext(
+		ctx,
+		&session,
+		"SELECT id, topic_id, status FROM sessions WHERE id = $1 FOR UPDATE",
+		sessionID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil, errs.New(errs.ErrNotFound, "session not found")
+		}
+
+		return 0, nil, errs.New(errs.ErrExecutionQuery, "tx.GetContext: "+err.Error())
+	}
+
+	if session.Status == entity.SessionStatusCompleted {
+		return 0, nil, errs.New(errs.ErrSessionCompleted, "answers can't be changed after completion")
+	}
+
+	var questionTopicID int
+	if err := tx.GetContext(ctx, &questionTopicID, "SELECT topic_id FROM questions WHERE id = $1", questionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil, errs.New(errs.ErrNotFound, "question not found")
+		}
+
+		return 0, nil, errs.New(errs.ErrExecutionQuery, "tx.GetContext: "+err.Error())
+	}
+
+	if questionTopicID != session.TopicID {
+		return 0, nil, errs.New(errs.ErrNotFound, "question not found in the session topic")
+	}
+
+	var previous Answer
+	err = tx.GetContext(
 		ctx,
-		"INSERT INTO answers (session_id, question_id, minio_filename) VALUES ($1, $2, $3)",
+		&previous,
+		`SELECT id, question_id, session_id, minio_filename, normalized_filename
+		 FROM answers WHERE session_id = $1 AND question_id = $2`,
 		sessionID,
 		questionID,
-		filename,
 	)
+
+	answerID := previous.ID
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		err = tx.GetContext(
+			ctx,
+			&answerID,
+			`INSERT INTO answers (session_id, question_id, minio_filename, content_type, size_bytes, checksum, duration_ms,
+			                      normalized_filename, speech_duration_ms, loudness_lufs)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			 RETURNING id`,
+			sessionID,
+			questionID,
+			file.ObjectName,
+			file.ContentType,
+			file.Size,
+			file.Checksum,
+			durationMS,
+			normalizedFilename,
+			speechDurationMS,
+			loudness,
+		)
+	case err == nil:
+		// Новая запись сбрасывает транскрипт и метрики старой
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE answers
+			 SET minio_filename = $2, content_type = $3, size_bytes = $4, checksum = $5, duration_ms = $6,
+			     normalized_filename = $7, speech_duration_ms = $8, loudness_lufs = $9,
+			     transcript = NULL, fluency = NULL, transcribed_at = NULL
+			 WHERE id = $1`,
+			previous.ID,
+			file.ObjectName,
+			file.ContentType,
+			file.Size,
+			file.Checksum,
+			durationMS,
+			normalizedFilename,
+			speechDurationMS,
+			loudness,
+		)
+	}
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == errCodeViolation {
-			return errs.New(errs.ErrNotFound, "session or question not found")
-		}
+		return 0, nil, errs.New(errs.ErrExecutionQuery, "tx.ExecContext: "+err.Error())
+	}
 
-		return errs.New(errs.ErrExecutionQuery, "s.db.ExecContext"+err.Error())
+	if err := tx.Commit(); err != nil {
+		return 0, nil, errs.New(errs.ErrExecutionQuery, "tx.Commit: "+err.Error())
 	}
 
-	return nil
+	if previous.ID == 0 {
+		return answerID, nil, nil
+	}
+
+	replaced := []string{previous.Filename}
+	if previous.NormalizedFilename != nil {
+		replaced = append(replaced, *previous.NormalizedFilename)
+	}
+
+	return answerID, replaced, nil
 }
 
 func (s *Storage) GetQuestionByID(ctx context.Context, id int) (Question, error) {
@@ -129,7 +249,8 @@ func (s *Storage) GetAnswerBySessionID(ctx context.Context, sessionID string) ([
 	if err := s.db.SelectContext(
 		ctx,
 		&answers,
-		"SELECT * FROM answers WHERE session_id = $1",
+		`SELECT id, question_id, session_id, minio_filename, normalized_filename, transcript, fluency
+		 FROM answers WHERE session_id = $1 ORDER BY id`,
 		sessionID,
 	); err != nil {
 		return nil, errs.New(errs.ErrExecutionQuery, "s.db.SelectContext"+err.Error())
@@ -138,6 +259,222 @@ func (s *Storage) GetAnswerBySessionID(ctx context.Context, sessionID string) ([
 	return answers, nil
 }
 
+func (s *Storage) GetSessionByID(ctx context.Context, sessionID string, userID int) (Session, error) {
+	var session Session
+	if err := s.db.GetContext(
+		ctx,
+		&session,
+		`SELECT s.id, s.user_id, s.topic_id, t.title AS topic_title, s.status, s.analysis,
+		        s.created_at, s.completed_at
+		 FROM sessions s
+		 JOIN topics t ON t.id = s.topic_id
+		 WHERE s.id = $1 AND s.user_id = $2`,
+		sessionID,
+		userID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Session{}, errs.New(errs.ErrNotFound, "session not found or access denied")
+		}
+
+		return Session{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return session, nil
+}
+
+func (s *Storage) GetUserSessions(ctx context.Context, userID, limit, offset int) ([]SessionSummary, error) {
+	var sessions []SessionSummary
+	if err := s.db.SelectContext(
+		ctx,
+		&sessions,
+		`SELECT s.id, s.topic_id, t.title AS topic_title, s.status, s.analysis->>'overall_level' AS overall_level,
+		        COUNT(a.id) AS answers_count, s.created_at, s.completed_at
+		 FROM sessions s
+		 JOIN topics t ON t.id = s.topic_id
+		 LEFT JOIN answers a ON a.session_id = s.id
+		 WHERE s.user_id = $1
+		 GROUP BY s.id, t.title
+		 ORDER BY s.created_at DESC
+		 LIMIT $2 OFFSET $3`,
+		userID,
+		limit,
+		offset,
+	); err != nil {
+		return nil, errs.New(errs.ErrExecutionQuery, "s.db.SelectContext: "+err.Error())
+	}
+
+	return sessions, nil
+}
+
+func (s *Storage) GetSessionAnswers(ctx context.Context, sessionID string) ([]SessionAnswer, error) {
+	var answers []SessionAnswer
+	if err := s.db.SelectContext(
+		ctx,
+		&answers,
+		`SELECT a.id, a.question_id, q.question_text, a.minio_filename, a.transcript
+		 FROM answers a
+		 JOIN questions q ON q.id = a.question_id
+		 WHERE a.session_id = $1
+		 ORDER BY a.id`,
+		sessionID,
+	); err != nil {
+		return nil, errs.New(errs.ErrExecutionQuery, "s.db.SelectContext: "+err.Error())
+	}
+
+	return answers, nil
+}
+
+// SaveAnswerTranscript stores the transcript of the recording stored as filename. Returns ErrAnswerReplaced when
+// the answer was re-recorded in the meantime, so the transcript of the old recording is never cached for the new one.
+func (s *Storage) SaveAnswerTranscript(ctx context.Context, answerID int, filename, transcript string, fluency []byte) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		`UPDATE answers SET transcript = $3, fluency = $4, transcribed_at = NOW()
+		 WHERE id = $1 AND minio_filename = $2`,
+		answerID,
+		filename,
+		transcript,
+		fluency,
+	)
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "s.db.ExecContext: "+err.Error())
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "res.RowsAffected: "+err.Error())
+	}
+
+	if rows == 0 {
+		return errs.New(errs.ErrAnswerReplaced, "the recording was replaced while it was transcribed")
+	}
+
+	return nil
+}
+
+// MarkSessionCompleted stores the analysis, the progress metrics and the grammar mistakes of the session
+// in one transaction, so the progress never disagrees with the analysis. A re-analysis keeps the completion
+// time of the session, otherwise an old session would move to the end of the progress series.
+func (s *Storage) MarkSessionCompleted(
+	ctx context.Context,
+	sessionID string,
+	analysis []byte,
+	prompt entity.PromptVersion,
+	metrics entity.SessionMetrics,
+	mistakes []entity.GrammarIssue,
+) error {
+	vocabulary, err := json.Marshal(metrics.Vocabulary)
+	if err != nil {
+		return errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "s.db.BeginTxx: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE sessions
+		 SET status = 'completed', analysis = $2, prompt_name = $3, prompt_version = $4,
+		     completed_at = COALESCE(completed_at, NOW())
+		 WHERE id = $1`,
+		sessionID,
+		analysis,
+		prompt.Name,
+		prompt.Version,
+	)
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "tx.ExecContext: "+err.Error())
+	}
+
+	// Повторный анализ заменяет метрики сессии, а не добавляет новую точку
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO session_metrics (session_id, user_id, overall_level, answers_count, grammar_issues, vocabulary,
+		                              words_per_minute, filler_rate, completed_at)
+		 SELECT id, user_id, $2, $3, $4, $5, $6, $7, completed_at
+		 FROM sessions WHERE id = $1 AND user_id IS NOT NULL
+		 ON CONFLICT (session_id) DO UPDATE
+		 SET overall_level = EXCLUDED.overall_level, answers_count = EXCLUDED.answers_count,
+		     grammar_issues = EXCLUDED.grammar_issues, vocabulary = EXCLUDED.vocabulary,
+		     words_per_minute = EXCLUDED.words_per_minute, filler_rate = EXCLUDED.filler_rate,
+		     completed_at = EXCLUDED.completed_at`,
+		sessionID,
+		metrics.OverallLevel,
+		metrics.AnswersCount,
+		metrics.GrammarIssues,
+		vocabulary,
+		metrics.WordsPerMinute,
+		metrics.FillerRate,
+	)
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "tx.ExecContext: "+err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM grammar_mistakes WHERE session_id = $1", sessionID)
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "tx.ExecContext: "+err.Error())
+	}
+
+	for _, mistake := range mistakes {
+		// Ошибки из анализов без категории (до появления каталога) не сохраняются
+		if mistake.Category == "" {
+			continue
+		}
+
+		var answerID *int
+		if mistake.AnswerID != 0 {
+			answerID = &mistake.AnswerID
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO grammar_mistakes (user_id, session_id, answer_id, category, sentence, corrected_sentence,
+			                               explanation, created_at)
+			 SELECT user_id, id, $2, $3, $4, $5, $6, completed_at
+			 FROM sessions WHERE id = $1 AND user_id IS NOT NULL`,
+			sessionID,
+			answerID,
+			mistake.Category,
+			mistake.Sentence,
+			mistake.CorrectedSentence,
+			mistake.Explanation,
+		)
+		if err != nil {
+			return errs.New(errs.ErrExecutionQuery, "tx.ExecContext: "+err.Error())
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errs.New(errs.ErrExecutionQuery, "tx.Commit: "+err.Error())
+	}
+
+	return nil
+}
+
+// GetUserSessionMetrics returns the metrics of the last limit completed sessions of the user, oldest first.
+func (s *Storage) GetUserSessionMetrics(ctx context.Context, userID, limit int) ([]SessionMetrics, error) {
+	var metrics []SessionMetrics
+	if err := s.db.SelectContext(
+		ctx,
+		&metrics,
+		`SELECT session_id, overall_level, answers_count, grammar_issues, vocabulary, words_per_minute, filler_rate,
+		        completed_at
+		 FROM (
+		     SELECT * FROM session_metrics WHERE user_id = $1 ORDER BY completed_at DESC LIMIT $2
+		 ) recent
+		 ORDER BY completed_at`,
+		userID,
+		limit,
+	); err != nil {
+		return nil, errs.New(errs.ErrExecutionQuery, "s.db.SelectContext: "+err.Error())
+	}
+
+	return metrics, nil
+}
+
 func (s *Storage) GetArticles(ctx context.Context, limit, offset int) ([]Article, error) {
 	var articles []Article
 	if err := s.db.SelectContext(
@@ -161,6 +498,10 @@ func (s *Storage) GetArticleByID(ctx context.Context, id int) (Article, error) {
 		"SELECT id, image_url, title, content, level, minutes_to_read FROM articles WHERE id = $1",
 		id,
 	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Article{}, errs.New(errs.ErrNotFound, "article not found")
+		}
+
 		return Article{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
 	}
 
@@ -213,39 +554,132 @@ func (s *Storage) CreateWordCollection(ctx context.Context, userID int, name, im
 	return collection, nil
 }
 
-func (s *Storage) DeleteWordCollection(ctx context.Context, collectionID uuid.UUID, userID int) error {
-	result, err := s.db.ExecContext(
+// DeleteWordCollection deletes the collection of the user and returns the path of its image when no other
+// collection uses the object, so that the caller can remove it.
+func (s *Storage) DeleteWordCollection(ctx context.Context, collectionID uuid.UUID, userID int) (string, error) {
+	var imagePath string
+	if err := s.db.GetContext(
+		ctx,
+		&imagePath,
+		`DELETE FROM word_collections WHERE id = $1 AND user_id = $2 RETURNING COALESCE(image_path, '')`,
+		collectionID,
+		userID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.New(errs.ErrNotFound, "collection not found or access denied")
+		}
+
+		return "", errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return orphanedImage(ctx, s.db, imagePath)
+}
+
+// UpdateWordCollection renames the collection of the user and replaces its image; nil values keep the current
+// ones and an empty imagePath removes the image. The second result is the path of the replaced image when no
+// collection uses the object anymore.
+func (s *Storage) UpdateWordCollection(
+	ctx context.Context,
+	collectionID string,
+	userID int,
+	name, imagePath *string,
+) (WordCollection, string, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return WordCollection{}, "", errs.New(errs.ErrExecutionQuery, "s.db.BeginTxx: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	var previousImage string
+	if err := tx.GetContext(
 		ctx,
-		`DELETE FROM word_collections WHERE id = $1 AND user_id = $2`,
+		&previousImage,
+		`SELECT COALESCE(image_path, '') FROM word_collections WHERE id = $1 AND user_id = $2 FOR UPDATE`,
 		collectionID,
 		userID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return WordCollection{}, "", errs.New(errs.ErrNotFound, "collection not found or access denied")
+		}
+
+		return WordCollection{}, "", errs.New(errs.ErrExecutionQuery, "tx.GetContext: "+err.Error())
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE word_collections
+		 SET name = COALESCE($2, name), image_path = COALESCE($3, image_path), updated_at = NOW()
+		 WHERE id = $1`,
+		collectionID,
+		name,
+		imagePath,
 	)
 	if err != nil {
-		return errs.New(errs.ErrExecutionQuery, "s.db.ExecContext: "+err.Error())
+		return WordCollection{}, "", errs.New(errs.ErrExecutionQuery, "tx.ExecContext: "+err.Error())
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	var replacedImage string
+	if imagePath != nil && *imagePath != previousImage {
+		replacedImage, err = orphanedImage(ctx, tx, previousImage)
+		if err != nil {
+			return WordCollection{}, "", err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return WordCollection{}, "", errs.New(errs.ErrExecutionQuery, "tx.Commit: "+err.Error())
+	}
+
+	collection, err := s.GetWordCollectionByID(ctx, collectionID, userID)
 	if err != nil {
-		return errs.New(errs.ErrExecutionQuery, "result.RowsAffected: "+err.Error())
+		return WordCollection{}, "", err
 	}
 
-	if rowsAffected == 0 {
-		return errs.New(errs.ErrNotFound, "collection not found or access denied")
+	return collection, replacedImage, nil
+}
+
+// orphanedImage returns the image path when no collection refers to it, and an empty string otherwise.
+func orphanedImage(ctx context.Context, q sqlx.QueryerContext, imagePath string) (string, error) {
+	if imagePath == "" {
+		return "", nil
 	}
 
-	return nil
+	// Раньше объекты назывались по имени файла клиента, поэтому одна картинка могла достаться нескольким коллекциям
+	var used bool
+	if err := sqlx.GetContext(
+		ctx,
+		q,
+		&used,
+		`SELECT EXISTS(SELECT 1 FROM word_collections WHERE image_path = $1)`,
+		imagePath,
+	); err != nil {
+		return "", errs.New(errs.ErrExecutionQuery, "sqlx.GetContext: "+err.Error())
+	}
+
+	if used {
+		return "", nil
+	}
+
+	return imagePath, nil
 }
 
+// currentStreak is the cached streak of the collection c, or 0 when the user u has not studied it today
+// or yesterday: the cache is only written by reviews, so a broken streak is noticed on read.
+const currentStreak = `CASE WHEN c.last_study_date >= (NOW() AT TIME ZONE COALESCE(u.time_zone, 'UTC'))::date - 1
+		                    THEN c.current_streak_days ELSE 0 END`
+
 func (s *Storage) GetUserCollections(ctx context.Context, userID int) ([]WordCollection, error) {
 	var collections []WordCollection
 	if err := s.db.SelectContext(
 		ctx,
 		&collections,
-		`SELECT id, user_id, name, image_path, total_words_count, learned_words_count,
-		        current_streak_days, longest_streak_days, last_studied_at, created_at, updated_at
-		 FROM word_collections 
-		 WHERE user_id = $1
-		 ORDER BY updated_at DESC`,
+		`SELECT c.id, c.user_id, c.name, c.image_path, c.total_words_count, c.learned_words_count,
+		        `+currentStreak+` AS current_streak_days, c.longest_streak_days, c.last_studied_at,
+		        c.created_at, c.updated_at
+		 FROM word_collections c
+		 LEFT JOIN users u ON u.id = c.user_id
+		 WHERE c.user_id = $1
+		 ORDER BY c.updated_at DESC`,
 		userID,
 	); err != nil {
 		return nil, errs.New(errs.ErrExecutionQuery, "s.db.SelectContext: "+err.Error())
@@ -259,14 +693,19 @@ func (s *Storage) GetWordCollectionByID(ctx context.Context, collectionID string
 	if err := s.db.GetContext(
 		ctx,
 		&collection,
-		`SELECT id, user_id, name, image_path, total_words_count, learned_words_count,
-		        current_streak_days, longest_streak_days, last_studied_at, 
-		        ai_suggestions, ai_suggestions_generated_at, created_at, updated_at
-		 FROM word_collections 
-		 WHERE id = $1 AND user_id = $2`,
+		`SELECT c.id, c.user_id, c.name, c.image_path, c.total_words_count, c.learned_words_count,
+		        `+currentStreak+` AS current_streak_days, c.longest_streak_days, c.last_studied_at,
+		        c.ai_suggestions, c.ai_suggestions_generated_at, c.created_at, c.updated_at
+		 FROM word_collections c
+		 LEFT JOIN users u ON u.id = c.user_id
+		 WHERE c.id = $1 AND c.user_id = $2`,
 		collectionID,
 		userID,
 	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return WordCollection{}, errs.New(errs.ErrNotFound, "collection not found or access denied")
+		}
+
 		return WordCollection{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
 	}
 
@@ -279,7 +718,8 @@ func (s *Storage) GetUserWordsByCollectionID(ctx context.Context, collectionID s
 		ctx,
 		&words,
 		`SELECT id, collection_id, word, translation, example, next_review_date,
-		        review_count, created_at, updated_at
+		        review_count, ease_factor, interval_days, repetitions, lapses, last_reviewed_at,
+		        learned_at, created_at, updated_at
 		 FROM user_words 
 		 WHERE collection_id = $1
 		 ORDER BY created_at DESC`,
@@ -291,33 +731,939 @@ func (s *Storage) GetUserWordsByCollectionID(ctx context.Context, collectionID s
 	return words, nil
 }
 
+// GetUserWordByID returns the word if it belongs to a collection of the user.
+func (s *Storage) GetUserWordByID(ctx context.Context, wordID string, userID int) (UserWord, error) {
+	var word UserWord
+	if err := s.db.GetContext(
+		ctx,
+		&word,
+		`SELECT w.id, w.collection_id, w.word, w.translation, w.example, w.next_review_date,
+		        w.review_count, w.ease_factor, w.interval_days, w.repetitions, w.lapses, w.last_reviewed_at,
+		        w.learned_at, w.created_at, w.updated_at
+		 FROM user_words w
+		 JOIN word_collections c ON c.id = w.collection_id
+		 WHERE w.id = $1 AND c.user_id = $2`,
+		wordID,
+		userID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UserWord{}, errs.New(errs.ErrNotFound, "word not found or access denied")
+		}
+
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return word, nil
+}
+
 func (s *Storage) AddWordToCollection(ctx context.Context, collectionID, word, translation string, example *string) (UserWord, error) {
-	var userWord UserWord
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "s.db.BeginTxx: "+err.Error())
+	}
+	defer tx.Rollback()
 
-	if err := s.db.GetContext(
+	var userWord UserWord
+	if err := tx.GetContext(
 		ctx,
 		&userWord,
 		`INSERT INTO user_words (collection_id, word, translation, example, next_review_date, review_count)
 		 VALUES ($1, $2, $3, $4, NOW(), 0)
-		 RETURNING id, collection_id, word, translation, example, next_review_date, review_count, created_at, updated_at`,
+		 RETURNING id, collection_id, word, translation, example, next_review_date, review_count,
+		           ease_factor, interval_days, repetitions, lapses, last_reviewed_at, learned_at, created_at, updated_at`,
 		collectionID,
 		word,
 		translation,
 		example,
 	); err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.GetContext: "+err.Error())
+	}
+
+	if err := adjustWordCounts(ctx, tx, collectionID, 1, 0); err != nil {
+		return UserWord{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.Commit: "+err.Error())
+	}
+
+	return userWord, nil
+}
+
+// UpdateUserWord changes the given fields of the word in the collection of the user, nil values keep
+// the current ones and an empty example removes it. The review schedule is kept.
+func (s *Storage) UpdateUserWord(
+	ctx context.Context,
+	collectionID, wordID string,
+	userID int,
+	update entity.UserWordUpdate,
+) (UserWord, error) {
+	var word UserWord
+	if err := s.db.GetContext(
+		ctx,
+		&word,
+		`UPDATE user_words w
+		 SET word = COALESCE($4, w.word),
+		     translation = COALESCE($5, w.translation),
+		     example = CASE WHEN $6::text IS NULL THEN w.example ELSE NULLIF($6, '') END,
+		     updated_at = NOW()
+		 FROM word_collections c
+		 WHERE w.id = $1 AND w.collection_id = $2 AND c.id = w.collection_id AND c.user_id = $3
+		 RETURNING w.id, w.collection_id, w.word, w.translation, w.example, w.next_review_date,
+		           w.review_count, w.ease_factor, w.interval_days, w.repetitions, w.lapses, w.last_reviewed_at,
+		           w.learned_at, w.created_at, w.updated_at`,
+		wordID,
+		collectionID,
+		userID,
+		update.Word,
+		update.Translation,
+		update.Example,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UserWord{}, errs.New(errs.ErrNotFound, "word not found or access denied")
+		}
+
 		return UserWord{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
 	}
 
-	_, err := s.db.ExecContext(
+	return word, nil
+}
+
+// DeleteUserWords deletes the words of the collection of the user and returns how many were deleted.
+// IDs of words from other collections are skipped.
+func (s *Storage) DeleteUserWords(ctx context.Context, collectionID string, userID int, wordIDs []string) (int, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, errs.New(errs.ErrExecutionQuery, "s.db.BeginTxx: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	var learned []bool
+	if err := tx.SelectContext(
 		ctx,
-		`UPDATE word_collections 
-		 SET total_words_count = total_words_count + 1, updated_at = NOW()
-		 WHERE id = $1`,
+		&learned,
+		`DELETE FROM user_words w
+		 USING word_collections c
+		 WHERE w.collection_id = $1 AND w.id = ANY($3::uuid[]) AND c.id = w.collection_id AND c.user_id = $2
+		 RETURNING w.learned_at IS NOT NULL`,
 		collectionID,
-	)
+		userID,
+		pq.Array(wordIDs),
+	); err != nil {
+		return 0, errs.New(errs.ErrExecutionQuery, "tx.SelectContext: "+err.Error())
+	}
+
+	if len(learned) == 0 {
+		return 0, nil
+	}
+
+	learnedCount := 0
+	for _, isLearned := range learned {
+		if isLearned {
+			learnedCount++
+		}
+	}
+
+	if err := adjustWordCounts(ctx, tx, collectionID, -len(learned), -learnedCount); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errs.New(errs.ErrExecutionQuery, "tx.Commit: "+err.Error())
+	}
+
+	return len(learned), nil
+}
+
+// MoveUserWord moves the word with its review schedule to another collection of the user.
+// The review history stays with the source collection: it is the history of studying that collection.
+func (s *Storage) MoveUserWord(ctx context.Context, collectionID, wordID, targetID string, userID int) (UserWord, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return UserWord{}, errs.New(errs.ErrExecutionQuery, "s.db.ExecContext: "+err.Error())
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "s.db.BeginTxx: "+err.Error())
 	}
+	defer tx.Rollback()
 
-	return userWord, nil
+	if err := lockCollections(ctx, tx, userID, collectionID, targetID); err != nil {
+		return UserWord{}, err
+	}
+
+	var word UserWord
+	if err := tx.GetContext(
+		ctx,
+		&word,
+		`UPDATE user_words
+		 SET collection_id = $3, updated_at = NOW()
+		 WHERE id = $1 AND collection_id = $2
+		 RETURNING id, collection_id, word, translation, example, next_review_date, review_count,
+		           ease_factor, interval_days, repetitions, lapses, last_reviewed_at, learned_at, created_at, updated_at`,
+		wordID,
+		collectionID,
+		targetID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UserWord{}, errs.New(errs.ErrNotFound, "word not found or access denied")
+		}
+
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.GetContext: "+err.Error())
+	}
+
+	learned := 0
+	if word.LearnedAt != nil {
+		learned = 1
+	}
+
+	if err := adjustWordCounts(ctx, tx, collectionID, -1, -learned); err != nil {
+		return UserWord{}, err
+	}
+
+	if err := adjustWordCounts(ctx, tx, targetID, 1, learned); err != nil {
+		return UserWord{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.Commit: "+err.Error())
+	}
+
+	return word, nil
+}
+
+// CopyUserWord adds a copy of the word to another collection of the user. The copy is a new word
+// and is studied from the beginning.
+func (s *Storage) CopyUserWord(ctx context.Context, collectionID, wordID, targetID string, userID int) (UserWord, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "s.db.BeginTxx: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	if err := lockCollections(ctx, tx, userID, collectionID, targetID); err != nil {
+		return UserWord{}, err
+	}
+
+	var word UserWord
+	if err := tx.GetContext(
+		ctx,
+		&word,
+		`INSERT INTO user_words (collection_id, word, translation, example, next_review_date, review_count)
+		 SELECT $3, word, translation, example, NOW(), 0
+		 FROM user_words
+		 WHERE id = $1 AND collection_id = $2
+		 RETURNING id, collection_id, word, translation, example, next_review_date, review_count,
+		           ease_factor, interval_days, repetitions, lapses, last_reviewed_at, learned_at, created_at, updated_at`,
+		wordID,
+		collectionID,
+		targetID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UserWord{}, errs.New(errs.ErrNotFound, "word not found or access denied")
+		}
+
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.GetContext: "+err.Error())
+	}
+
+	if err := adjustWordCounts(ctx, tx, targetID, 1, 0); err != nil {
+		return UserWord{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.Commit: "+err.Error())
+	}
+
+	return word, nil
+}
+
+// lockCollections locks the collections of the user for a change of their words and returns ErrNotFound
+// when any of them belongs to another user or doesn't exist.
+func lockCollections(ctx context.Context, tx *sqlx.Tx, userID int, collectionIDs ...string) error {
+	var locked []string
+	// Блокировки берутся в порядке id, чтобы встречные переносы между двумя коллекциями не взаимоблокировались
+	if err := tx.SelectContext(
+		ctx,
+		&locked,
+		`SELECT id FROM word_collections WHERE id = ANY($1::uuid[]) AND user_id = $2 ORDER BY id FOR UPDATE`,
+		pq.Array(collectionIDs),
+		userID,
+	); err != nil {
+		return errs.New(errs.ErrExecutionQuery, "tx.SelectContext: "+err.Error())
+	}
+
+	if len(locked) != len(collectionIDs) {
+		return errs.New(errs.ErrNotFound, "collection not found or access denied")
+	}
+
+	return nil
+}
+
+// adjustWordCounts changes the cached word counters of the collection within the transaction of the change.
+// A counter going below zero means the cache is out of sync with user_words, so the change is rejected.
+func adjustWordCounts(ctx context.Context, tx *sqlx.Tx, collectionID string, total, learned int) error {
+	result, err := tx.ExecContext(
+		ctx,
+		`UPDATE word_collections
+		 SET total_words_count = total_words_count + $2,
+		     learned_words_count = learned_words_count + $3,
+		     updated_at = NOW()
+		 WHERE id = $1 AND total_words_count + $2 >= 0 AND learned_words_count + $3 >= 0`,
+		collectionID,
+		total,
+		learned,
+	)
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "tx.ExecContext: "+err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "result.RowsAffected: "+err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return errs.New(errs.ErrExecutionQuery, "word counters of the collection would become negative: "+collectionID)
+	}
+
+	return nil
+}
+
+// GetDueUserWords returns the words of the collection due for review, the longest overdue first.
+func (s *Storage) GetDueUserWords(ctx context.Context, collectionID string, limit int) ([]UserWord, error) {
+	var words []UserWord
+	if err := s.db.SelectContext(
+		ctx,
+		&words,
+		`SELECT id, collection_id, word, translation, example, next_review_date,
+		        review_count, ease_factor, interval_days, repetitions, lapses, last_reviewed_at,
+		        learned_at, created_at, updated_at
+		 FROM user_words
+		 WHERE collection_id = $1 AND next_review_date <= NOW()
+		 ORDER BY next_review_date, created_at
+		 LIMIT $2`,
+		collectionID,
+		limit,
+	); err != nil {
+		return nil, errs.New(errs.ErrExecutionQuery, "s.db.SelectContext: "+err.Error())
+	}
+
+	return words, nil
+}
+
+func (s *Storage) CountDueUserWords(ctx context.Context, collectionID string) (int, error) {
+	var count int
+	if err := s.db.GetContext(
+		ctx,
+		&count,
+		`SELECT COUNT(*) FROM user_words WHERE collection_id = $1 AND next_review_date <= NOW()`,
+		collectionID,
+	); err != nil {
+		return 0, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return count, nil
+}
+
+// SaveWordReview saves the schedule after a review, adds the review to the history and updates the learned
+// words count and the study streak of the collection in one transaction. reviewCount is the number of reviews
+// the schedule was computed from: if another review was saved in between, ErrReviewConflict is returned.
+func (s *Storage) SaveWordReview(
+	ctx context.Context,
+	wordID string,
+	userID int,
+	reviewCount int,
+	review entity.WordReview,
+) (UserWord, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "s.db.BeginTxx: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	// Блокировка коллекции упорядочивает повторения ее слов, чтобы счетчики и серия не расходились с историей
+	var previous reviewedWord
+	if err := tx.GetContext(
+		ctx,
+		&previous,
+		`SELECT w.collection_id, w.learned_at IS NOT NULL AS learned,
+		        TO_CHAR(NOW() AT TIME ZONE COALESCE(u.time_zone, 'UTC'), 'YYYY-MM-DD') AS study_date
+		 FROM user_words w
+		 JOIN word_collections c ON c.id = w.collection_id
+		 LEFT JOIN users u ON u.id = c.user_id
+		 WHERE w.id = $1 AND c.user_id = $2 AND w.review_count = $3
+		 FOR UPDATE OF w, c`,
+		wordID,
+		userID,
+		reviewCount,
+	); err != nil {
+		// Слово уже проверено владельцем, поэтому пустой результат означает параллельное повторение
+		if errors.Is(err, sql.ErrNoRows) {
+			return UserWord{}, errs.New(errs.ErrReviewConflict, "word was reviewed by another request")
+		}
+
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.GetContext: "+err.Error())
+	}
+
+	var word UserWord
+	if err := tx.GetContext(
+		ctx,
+		&word,
+		`UPDATE user_words
+		 SET ease_factor = $2,
+		     interval_days = $3,
+		     repetitions = $4,
+		     lapses = $5,
+		     learned_at = CASE WHEN $6 THEN COALESCE(learned_at, NOW()) END,
+		     review_count = review_count + 1,
+		     next_review_date = NOW() + make_interval(days => $3),
+		     last_reviewed_at = NOW(),
+		     updated_at = NOW()
+		 WHERE id = $1
+		 RETURNING id, collection_id, word, translation, example, next_review_date,
+		           review_count, ease_factor, interval_days, repetitions, lapses, last_reviewed_at,
+		           learned_at, created_at, updated_at`,
+		wordID,
+		review.Schedule.EaseFactor,
+		review.Schedule.IntervalDays,
+		review.Schedule.Repetitions,
+		review.Schedule.Lapses,
+		review.Learned,
+	); err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.GetContext: "+err.Error())
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO word_reviews (word_id, collection_id, user_id, grade, ease_factor, interval_days, learned, study_date)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		wordID,
+		previous.CollectionID,
+		userID,
+		review.Grade,
+		review.Schedule.EaseFactor,
+		review.Schedule.IntervalDays,
+		review.Learned,
+		previous.StudyDate,
+	)
+	if err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.ExecContext: "+err.Error())
+	}
+
+	learnedDelta := 0
+	if review.Learned && !previous.Learned {
+		learnedDelta = 1
+	} else if !review.Learned && previous.Learned {
+		learnedDelta = -1
+	}
+
+	// Серия продолжается, если прошлое занятие было вчера, и начинается заново после пропущенного дня
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE word_collections c
+		 SET learned_words_count = c.learned_words_count + $2,
+		     current_streak_days = streak.days,
+		     longest_streak_days = GREATEST(c.longest_streak_days, streak.days),
+		     last_study_date = GREATEST(c.last_study_date, $3::date),
+		     last_studied_at = NOW()
+		 FROM (SELECT CASE
+		                  WHEN last_study_date >= $3::date THEN GREATEST(current_streak_days, 1)
+		                  WHEN last_study_date = $3::date - 1 THEN current_streak_days + 1
+		                  ELSE 1
+		              END AS days
+		       FROM word_collections WHERE id = $1) streak
+		 WHERE c.id = $1`,
+		previous.CollectionID,
+		learnedDelta,
+		previous.StudyDate,
+	)
+	if err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.ExecContext: "+err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.Commit: "+err.Error())
+	}
+
+	return word, nil
+}
+
+// GetWordSuggestions returns the cached suggestions of the collection of the user.
+func (s *Storage) GetWordSuggestions(ctx context.Context, collectionID string, userID int, ttl time.Duration) (WordSuggestions, error) {
+	var suggestions WordSuggestions
+	if err := s.db.GetContext(
+		ctx,
+		&suggestions,
+		`SELECT ai_suggestions, ai_suggestions_generated_at,
+		        COALESCE(ai_suggestions_generated_at > NOW() - make_interval(secs => $3), FALSE) AS fresh
+		 FROM word_collections
+		 WHERE id = $1 AND user_id = $2`,
+		collectionID,
+		userID,
+		ttl.Seconds(),
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return WordSuggestions{}, errs.New(errs.ErrNotFound, "collection not found or access denied")
+		}
+
+		return WordSuggestions{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return suggestions, nil
+}
+
+// SaveWordSuggestions replaces the cached suggestions of the collection and returns the generation time.
+func (s *Storage) SaveWordSuggestions(ctx context.Context, collectionID string, suggestions []byte) (string, error) {
+	var generatedAt string
+	if err := s.db.GetContext(
+		ctx,
+		&generatedAt,
+		`UPDATE word_collections
+		 SET ai_suggestions = $2, ai_suggestions_generated_at = NOW()
+		 WHERE id = $1
+		 RETURNING ai_suggestions_generated_at`,
+		collectionID,
+		suggestions,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.New(errs.ErrNotFound, "collection not found")
+		}
+
+		return "", errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return generatedAt, nil
+}
+
+// AcceptWordSuggestion removes the suggestion from the cached ones and adds the word to the collection in one
+// transaction. ErrNotFound is returned when the collection has no such suggestion, e.g. it was already accepted.
+// ErrAlreadyExists is returned when the collection already has the word; the suggestion is removed anyway.
+func (s *Storage) AcceptWordSuggestion(ctx context.Context, collectionID string, suggestion entity.AISuggestion) (UserWord, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "s.db.BeginTxx: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	// Строка коллекции блокируется обновлением, поэтому одну рекомендацию нельзя принять дважды
+	result, err := tx.ExecContext(
+		ctx,
+		`UPDATE word_collections
+		 SET ai_suggestions = (SELECT COALESCE(jsonb_agg(s), '[]'::jsonb)
+		                       FROM jsonb_array_elements(ai_suggestions) s
+		                       WHERE s->>'word' <> $2),
+		     updated_at = NOW()
+		 WHERE id = $1 AND ai_suggestions @> jsonb_build_array(jsonb_build_object('word', $2::text))`,
+		collectionID,
+		suggestion.Word,
+	)
+	if err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.ExecContext: "+err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "result.RowsAffected: "+err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return UserWord{}, errs.New(errs.ErrNotFound, "suggestion not found: "+suggestion.Word)
+	}
+
+	// Пользователь мог добавить слово вручную после генерации рекомендаций.
+	// Проверка идёт под блокировкой коллекции, поэтому параллельные принятия не добавят дубликат
+	var exists bool
+	if err := tx.GetContext(
+		ctx,
+		&exists,
+		"SELECT EXISTS(SELECT 1 FROM user_words WHERE collection_id = $1 AND lower(word) = lower($2))",
+		collectionID,
+		suggestion.Word,
+	); err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.GetContext: "+err.Error())
+	}
+
+	// Рекомендация всё равно убирается, иначе её предлагали бы снова и каждое принятие заканчивалось бы конфликтом
+	if exists {
+		if err := tx.Commit(); err != nil {
+			return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.Commit: "+err.Error())
+		}
+
+		return UserWord{}, errs.New(errs.ErrAlreadyExists, "word is already in the collection: "+suggestion.Word)
+	}
+
+	var word UserWord
+	if err := tx.GetContext(
+		ctx,
+		&word,
+		`INSERT INTO user_words (collection_id, word, translation, next_review_date, review_count)
+		 VALUES ($1, $2, $3, NOW(), 0)
+		 RETURNING id, collection_id, word, translation, example, next_review_date, review_count,
+		           ease_factor, interval_days, repetitions, lapses, last_reviewed_at, learned_at, created_at, updated_at`,
+		collectionID,
+		suggestion.Word,
+		suggestion.Translation,
+	); err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.GetContext: "+err.Error())
+	}
+
+	if err := adjustWordCounts(ctx, tx, collectionID, 1, 0); err != nil {
+		return UserWord{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return UserWord{}, errs.New(errs.ErrExecutionQuery, "tx.Commit: "+err.Error())
+	}
+
+	return word, nil
+}
+
+func (s *Storage) CreateUser(ctx context.Context, email, passwordHash string) (User, error) {
+	var user User
+	if err := s.db.QueryRowxContext(
+		ctx,
+		`INSERT INTO users (email, password_hash)
+		 VALUES ($1, $2)
+		 RETURNING id, email, password_hash, created_at, updated_at`,
+		email,
+		passwordHash,
+	).StructScan(&user); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == errCodeUniqueViolation {
+			return User{}, errs.New(errs.ErrAlreadyExists, "user with this email already exists")
+		}
+
+		return User{}, errs.New(errs.ErrExecutionQuery, "s.db.QueryRowxContext: "+err.Error())
+	}
+
+	return user, nil
+}
+
+func (s *Storage) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	var user User
+	if err := s.db.GetContext(
+		ctx,
+		&user,
+		"SELECT id, email, password_hash, created_at, updated_at FROM users WHERE email = $1",
+		email,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return User{}, errs.New(errs.ErrNotFound, "user not found")
+		}
+
+		return User{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return user, nil
+}
+
+func (s *Storage) GetUserSettings(ctx context.Context, userID int) (UserSettings, error) {
+	var settings UserSettings
+	if err := s.db.GetContext(
+		ctx,
+		&settings,
+		"SELECT feedback_language, time_zone FROM users WHERE id = $1",
+		userID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UserSettings{}, errs.New(errs.ErrNotFound, "user not found")
+		}
+
+		return UserSettings{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return settings, nil
+}
+
+// UpdateUserSettings changes the given settings, nil values keep the current ones.
+func (s *Storage) UpdateUserSettings(ctx context.Context, userID int, update entity.UserSettingsUpdate) (UserSettings, error) {
+	var settings UserSettings
+	if err := s.db.GetContext(
+		ctx,
+		&settings,
+		`UPDATE users
+		 SET feedback_language = COALESCE($2, feedback_language),
+		     time_zone = COALESCE($3, time_zone),
+		     updated_at = NOW()
+		 WHERE id = $1
+		 RETURNING feedback_language, time_zone`,
+		userID,
+		update.FeedbackLanguage,
+		update.TimeZone,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UserSettings{}, errs.New(errs.ErrNotFound, "user not found")
+		}
+
+		return UserSettings{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return settings, nil
+}
+
+const jobColumns = `id, type, user_id, payload, status, progress, result, attempts, max_attempts,
+		        last_error, run_at, created_at, updated_at, finished_at`
+
+func (s *Storage) CreateJob(ctx context.Context, jobType string, userID int, dedupeKey string, payload []byte, maxAttempts int) (Job, error) {
+	var job Job
+	if err := s.db.QueryRowxContext(
+		ctx,
+		`INSERT INTO jobs (type, user_id, dedupe_key, payload, max_attempts)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING `+jobColumns,
+		jobType,
+		userID,
+		dedupeKey,
+		payload,
+		maxAttempts,
+	).StructScan(&job); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == errCodeUniqueViolation {
+			return Job{}, errs.New(errs.ErrAlreadyExists, "active job already exists")
+		}
+
+		return Job{}, errs.New(errs.ErrExecutionQuery, "s.db.QueryRowxContext: "+err.Error())
+	}
+
+	return job, nil
+}
+
+func (s *Storage) GetActiveJobByDedupeKey(ctx context.Context, dedupeKey string) (Job, error) {
+	var job Job
+	if err := s.db.GetContext(
+		ctx,
+		&job,
+		`SELECT `+jobColumns+`
+		 FROM jobs
+		 WHERE dedupe_key = $1 AND status IN ('queued', 'running')`,
+		dedupeKey,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Job{}, errs.New(errs.ErrNotFound, "active job not found")
+		}
+
+		return Job{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return job, nil
+}
+
+func (s *Storage) GetJobByID(ctx context.Context, jobID string, userID int) (Job, error) {
+	var job Job
+	if err := s.db.GetContext(
+		ctx,
+		&job,
+		`SELECT `+jobColumns+`
+		 FROM jobs
+		 WHERE id = $1 AND user_id = $2`,
+		jobID,
+		userID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Job{}, errs.New(errs.ErrNotFound, "job not found or access denied")
+		}
+
+		return Job{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return job, nil
+}
+
+// ClaimJob locks the next due job for the given lease. Running jobs whose lease expired
+// (e.g. the worker crashed) are claimed again. Returns ErrNotFound when the queue is empty.
+func (s *Storage) ClaimJob(ctx context.Context, lease time.Duration) (Job, error) {
+	var job Job
+	if err := s.db.GetContext(
+		ctx,
+		&job,
+		`UPDATE jobs
+		 SET status = 'running', attempts = attempts + 1, last_error = NULL,
+		     locked_until = NOW() + make_interval(secs => $1), updated_at = NOW()
+		 WHERE id = (
+		     SELECT id FROM jobs
+		     WHERE (status = 'queued' AND run_at <= NOW())
+		        OR (status = 'running' AND locked_until < NOW())
+		     ORDER BY run_at
+		     LIMIT 1
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING `+jobColumns,
+		lease.Seconds(),
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Job{}, errs.New(errs.ErrNotFound, "no due jobs")
+		}
+
+		return Job{}, errs.New(errs.ErrExecutionQuery, "s.db.GetContext: "+err.Error())
+	}
+
+	return job, nil
+}
+
+// UpdateJobProgress, SucceedJob, RetryJob and FailJob only touch the job while the given attempt still holds it:
+// once the lease expires another worker claims the job again and bumps attempts, so a late writer gets ErrNotFound
+// instead of overwriting the newer attempt.
+func (s *Storage) UpdateJobProgress(ctx context.Context, jobID string, attempt, progress int) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		`UPDATE jobs SET progress = $3, updated_at = NOW()
+		 WHERE id = $1 AND attempts = $2 AND status = 'running'`,
+		jobID,
+		attempt,
+		progress,
+	)
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "s.db.ExecContext: "+err.Error())
+	}
+
+	return checkJobLease(res)
+}
+
+func (s *Storage) SucceedJob(ctx context.Context, jobID string, attempt int, result []byte) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		`UPDATE jobs
+		 SET status = 'succeeded', progress = 100, result = $3, locked_until = NULL,
+		     updated_at = NOW(), finished_at = NOW()
+		 WHERE id = $1 AND attempts = $2 AND status = 'running'`,
+		jobID,
+		attempt,
+		result,
+	)
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "s.db.ExecContext: "+err.Error())
+	}
+
+	return checkJobLease(res)
+}
+
+func (s *Storage) RetryJob(ctx context.Context, jobID string, attempt int, lastError string, runAt time.Time) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		`UPDATE jobs
+		 SET status = 'queued', last_error = $3, run_at = $4, locked_until = NULL, updated_at = NOW()
+		 WHERE id = $1 AND attempts = $2 AND status = 'running'`,
+		jobID,
+		attempt,
+		lastError,
+		runAt,
+	)
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "s.db.ExecContext: "+err.Error())
+	}
+
+	return checkJobLease(res)
+}
+
+func (s *Storage) FailJob(ctx context.Context, jobID string, attempt int, lastError string) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		`UPDATE jobs
+		 SET status = 'failed', last_error = $3, locked_until = NULL, updated_at = NOW(), finished_at = NOW()
+		 WHERE id = $1 AND attempts = $2 AND status = 'running'`,
+		jobID,
+		attempt,
+		lastError,
+	)
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "s.db.ExecContext: "+err.Error())
+	}
+
+	return checkJobLease(res)
+}
+
+func checkJobLease(res sql.Result) error {
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(errs.ErrExecutionQuery, "res.RowsAffected: "+err.Error())
+	}
+
+	if rows == 0 {
+		return errs.New(errs.ErrNotFound, "job lease lost: the job was claimed by another attempt")
+	}
+
+	return nil
+}
+
+// GetGrammarMistakeStats ranks the grammar rule categories of the user's mistakes, the most frequent first.
+func (s *Storage) GetGrammarMistakeStats(ctx context.Context, userID, limit int) ([]GrammarMistakeStat, error) {
+	var stats []GrammarMistakeStat
+	if err := s.db.SelectContext(
+		ctx,
+		&stats,
+		`SELECT category, COUNT(*) AS mistakes, COUNT(DISTINCT session_id) AS sessions, MAX(created_at) AS last_seen_at
+		 FROM grammar_mistakes
+		 WHERE user_id = $1
+		 GROUP BY category
+		 ORDER BY mistakes DESC, last_seen_at DESC
+		 LIMIT $2`,
+		userID,
+		limit,
+	); err != nil {
+		return nil, errs.New(errs.ErrExecutionQuery, "s.db.SelectContext: "+err.Error())
+	}
+
+	return stats, nil
+}
+
+// GetGrammarMistakeExamples returns up to perCategory latest mistakes of the user in every category.
+func (s *Storage) GetGrammarMistakeExamples(
+	ctx context.Context,
+	userID int,
+	categories []string,
+	perCategory int,
+) ([]GrammarMistake, error) {
+	var mistakes []GrammarMistake
+	if err := s.db.SelectContext(
+		ctx,
+		&mistakes,
+		`SELECT session_id, answer_id, category, sentence, corrected_sentence, explanation, created_at
+		 FROM (
+		     SELECT *, ROW_NUMBER() OVER (PARTITION BY category ORDER BY created_at DESC, id DESC) AS position
+		     FROM grammar_mistakes
+		     WHERE user_id = $1 AND category = ANY($2)
+		 ) ranked
+		 WHERE position <= $3
+		 ORDER BY category, position`,
+		userID,
+		pq.Array(categories),
+		perCategory,
+	); err != nil {
+		return nil, errs.New(errs.ErrExecutionQuery, "s.db.SelectContext: "+err.Error())
+	}
+
+	return mistakes, nil
+}
+
+// GetArticlesByGrammarCategories returns up to perCategory articles with a grammar rule of every category,
+// the articles closest to the given level first.
+func (s *Storage) GetArticlesByGrammarCategories(
+	ctx context.Context,
+	categories []string,
+	level string,
+	perCategory int,
+) ([]CategoryArticle, error) {
+	var articles []CategoryArticle
+	if err := s.db.SelectContext(
+		ctx,
+		&articles,
+		`SELECT category, id, image_url, title, level, minutes_to_read
+		 FROM (
+		     SELECT matched.*, ROW_NUMBER() OVER (
+		         PARTITION BY category
+		         ORDER BY COALESCE(ABS(array_position($2::TEXT[], level) - array_position($2::TEXT[], $3)), 6),
+		                  created_at DESC
+		     ) AS position
+		     FROM (
+		         SELECT DISTINCT r.category, a.id, a.image_url, a.title, a.level, a.minutes_to_read, a.created_at
+		         FROM article_grammar_rules r
+		         JOIN articles a ON a.id = r.article_id
+		         WHERE r.category = ANY($1)
+		     ) matched
+		 ) ranked
+		 WHERE position <= $4
+		 ORDER BY category, position`,
+		pq.Array(categories),
+		pq.Array(entity.CEFRLevels),
+		level,
+		perCategory,
+	); err != nil {
+		return nil, errs.New(errs.ErrExecutionQuery, "s.db.SelectContext: "+err.Error())
+	}
+
+	return articles, nil
 }
@@ -0,0 +1,187 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"speech-processing-service/internal/errs"
+)
+
+// SniffLen is how many leading bytes Sniff needs.
+const SniffLen = 512
+
+type Format struct {
+	MIMEType  string
+	Extension string
+}
+
+var (
+	FormatWAV  = Format{MIMEType: "audio/wav", Extension: "wav"}
+	FormatMP3  = Format{MIMEType: "audio/mpeg", Extension: "mp3"}
+	FormatAAC  = Format{MIMEType: "audio/aac", Extension: "aac"}
+	FormatMP4  = Format{MIMEType: "audio/mp4", Extension: "m4a"}
+	FormatOGG  = Format{MIMEType: "audio/ogg", Extension: "ogg"}
+	FormatFLAC = Format{MIMEType: "audio/flac", Extension: "flac"}
+	FormatWebM = Format{MIMEType: "audio/webm", Extension: "webm"}
+)
+
+// Sniff detects the audio container by its magic bytes; the declared Content-Type is not trusted.
+func Sniff(head []byte) (Format, error) {
+	switch {
+	case len(head) >= 12 && bytes.Equal(head[:4], []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WAVE")):
+		return FormatWAV, nil
+	case len(head) >= 8 && bytes.Equal(head[4:8], []byte("ftyp")):
+		return FormatMP4, nil
+	case bytes.HasPrefix(head, []byte("OggS")):
+		return FormatOGG, nil
+	case bytes.HasPrefix(head, []byte("fLaC")):
+		return FormatFLAC, nil
+	case bytes.HasPrefix(head, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return FormatWebM, nil
+	case bytes.HasPrefix(head, []byte("ID3")):
+		return FormatMP3, nil
+	// ADTS и MPEG audio начинаются с синхрослова, у ADTS layer всегда 00
+	case len(head) >= 2 && head[0] == 0xFF && head[1]&0xF6 == 0xF0:
+		return FormatAAC, nil
+	case len(head) >= 2 && head[0] == 0xFF && head[1]&0xE0 == 0xE0:
+		return FormatMP3, nil
+	default:
+		return Format{}, errs.New(errs.ErrInvalidAudio, "unsupported audio format")
+	}
+}
+
+// Duration reads the duration from the container header. ok is false when the format does not
+// store it in the header (mp3, ogg, webm, ...) or the header is damaged.
+func Duration(r io.ReaderAt, size int64, format Format) (time.Duration, bool) {
+	switch format {
+	case FormatWAV:
+		return wavDuration(r, size)
+	case FormatMP4:
+		return mp4Duration(r, size)
+	default:
+		return 0, false
+	}
+}
+
+func wavDuration(r io.ReaderAt, size int64) (time.Duration, bool) {
+	var (
+		byteRate uint32
+		header   [8]byte
+	)
+
+	// Чанки идут после 12-байтового заголовка RIFF, размер чанка выравнивается до четного
+	for offset := int64(12); offset+8 <= size; {
+		if _, err := r.ReadAt(header[:], offset); err != nil {
+			return 0, false
+		}
+
+		chunkSize := int64(binary.LittleEndian.Uint32(header[4:]))
+
+		switch string(header[:4]) {
+		case "fmt ":
+			var rate [4]byte
+			if _, err := r.ReadAt(rate[:], offset+16); err != nil {
+				return 0, false
+			}
+
+			byteRate = binary.LittleEndian.Uint32(rate[:])
+		case "data":
+			if byteRate == 0 {
+				return 0, false
+			}
+
+			// Рекордеры, пишущие потоком, оставляют размер незаполненным
+			chunkSize = min(chunkSize, size-offset-8)
+
+			return time.Duration(float64(chunkSize) / float64(byteRate) * float64(time.Second)), true
+		}
+
+		offset += 8 + chunkSize + chunkSize%2
+	}
+
+	return 0, false
+}
+
+func mp4Duration(r io.ReaderAt, size int64) (time.Duration, bool) {
+	moovOffset, moovSize, ok := findBox(r, 0, size, "moov")
+	if !ok {
+		return 0, false
+	}
+
+	mvhdOffset, _, ok := findBox(r, moovOffset, moovOffset+moovSize, "mvhd")
+	if !ok {
+		return 0, false
+	}
+
+	var version [1]byte
+	if _, err := r.ReadAt(version[:], mvhdOffset); err != nil {
+		return 0, false
+	}
+
+	var timescale, duration uint64
+
+	// version 0 хранит времена в 32 битах, version 1 - в 64
+	if version[0] == 1 {
+		var fields [20]byte
+		if _, err := r.ReadAt(fields[:], mvhdOffset+20); err != nil {
+			return 0, false
+		}
+
+		timescale = uint64(binary.BigEndian.Uint32(fields[:4]))
+		duration = binary.BigEndian.Uint64(fields[4:12])
+	} else {
+		var fields [8]byte
+		if _, err := r.ReadAt(fields[:], mvhdOffset+12); err != nil {
+			return 0, false
+		}
+
+		timescale = uint64(binary.BigEndian.Uint32(fields[:4]))
+		duration = uint64(binary.BigEndian.Uint32(fields[4:]))
+	}
+
+	if timescale == 0 {
+		return 0, false
+	}
+
+	return time.Duration(float64(duration) / float64(timescale) * float64(time.Second)), true
+}
+
+// findBox looks for a box among the siblings in [start, end) and returns its payload offset and size.
+func findBox(r io.ReaderAt, start, end int64, boxType string) (int64, int64, bool) {
+	var header [16]byte
+
+	for offset := start; offset+8 <= end; {
+		if _, err := r.ReadAt(header[:8], offset); err != nil {
+			return 0, 0, false
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(header[:4]))
+		headerSize := int64(8)
+
+		switch boxSize {
+		case 0:
+			boxSize = end - offset
+		case 1:
+			if _, err := r.ReadAt(header[8:16], offset+8); err != nil {
+				return 0, 0, false
+			}
+
+			boxSize = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+
+		if boxSize < headerSize {
+			return 0, 0, false
+		}
+
+		if string(header[4:8]) == boxType {
+			return offset + headerSize, boxSize - headerSize, true
+		}
+
+		offset += boxSize
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,156 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/drivers/tools/audio"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+const (
+	sampleRate = "16000"
+
+	// Тишина срезается с обоих концов: areverse позволяет применить фильтр начала к концу записи
+	trimFilter = "silenceremove=start_periods=1:start_threshold=-50dB:start_silence=0.1," +
+		"areverse," +
+		"silenceremove=start_periods=1:start_threshold=-50dB:start_silence=0.1," +
+		"areverse"
+)
+
+// integratedLoudness matches the summary line of the ebur128 filter, e.g. "I:         -23.0 LUFS".
+var integratedLoudness = regexp.MustCompile(`I:\s+(-?[0-9.]+|-inf) LUFS`)
+
+// FFmpeg converts recordings to 16kHz mono wav with trimmed silence and measures them.
+type FFmpeg struct {
+	binary string
+}
+
+func New(cfg *config.Answers) (FFmpeg, error) {
+	if _, err := exec.LookPath(cfg.FFmpegBinary); err != nil {
+		return FFmpeg{}, errs.New(errs.ErrInitialization, "ffmpeg: "+err.Error())
+	}
+
+	return FFmpeg{
+		binary: cfg.FFmpegBinary,
+	}, nil
+}
+
+func (f *FFmpeg) Normalize(ctx context.Context, src io.Reader, extension string) (entity.NormalizedAudio, error) {
+	dir, err := os.MkdirTemp("", "answer-*")
+	if err != nil {
+		return entity.NormalizedAudio{}, errs.New(errs.ErrUseCaseExecution, "os.MkdirTemp: "+err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	// mp4-контейнеры могут хранить индекс в конце файла, поэтому ffmpeg читает файл, а не stdin
+	input := filepath.Join(dir, "input."+extension)
+	output := filepath.Join(dir, "output.wav")
+
+	if err := writeFile(input, src); err != nil {
+		return entity.NormalizedAudio{}, err
+	}
+
+	if _, err := f.run(ctx,
+		"-i", input,
+		"-vn",
+		"-af", trimFilter,
+		"-ac", "1",
+		"-ar", sampleRate,
+		"-c:a", "pcm_s16le",
+		output,
+	); err != nil {
+		return entity.NormalizedAudio{}, errs.New(errs.ErrInvalidAudio, "transcode: "+err.Error())
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		return entity.NormalizedAudio{}, errs.New(errs.ErrUseCaseExecution, "os.ReadFile: "+err.Error())
+	}
+
+	duration, ok := audio.Duration(bytes.NewReader(data), int64(len(data)), audio.FormatWAV)
+	if !ok {
+		return entity.NormalizedAudio{}, errs.New(errs.ErrUseCaseExecution, "ffmpeg: unreadable wav output")
+	}
+
+	if duration == 0 {
+		return entity.NormalizedAudio{}, errs.New(errs.ErrInvalidAudio, "recording contains only silence")
+	}
+
+	stderr, err := f.run(ctx, "-nostats", "-i", output, "-af", "ebur128=framelog=verbose", "-f", "null", "-")
+	if err != nil {
+		return entity.NormalizedAudio{}, errs.New(errs.ErrUseCaseExecution, "measure loudness: "+err.Error())
+	}
+
+	return entity.NormalizedAudio{
+		Data:        data,
+		ContentType: audio.FormatWAV.MIMEType,
+		Extension:   audio.FormatWAV.Extension,
+		Duration:    duration,
+		Loudness:    parseLoudness(stderr),
+	}, nil
+}
+
+// run executes ffmpeg and returns its stderr, where ffmpeg writes all diagnostics.
+func (f *FFmpeg) run(ctx context.Context, args ...string) (string, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, f.binary, append([]string{"-hide_banner", "-y"}, args...)...)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errs.New(errs.ErrExecutionRequest, "ffmpeg: "+err.Error()+": "+lastLine(stderr.String()))
+	}
+
+	return stderr.String(), nil
+}
+
+func writeFile(path string, src io.Reader) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errs.New(errs.ErrUseCaseExecution, "os.Create: "+err.Error())
+	}
+
+	if _, err := io.Copy(file, src); err != nil {
+		file.Close()
+
+		return errs.New(errs.ErrUseCaseExecution, "io.Copy: "+err.Error())
+	}
+
+	if err := file.Close(); err != nil {
+		return errs.New(errs.ErrUseCaseExecution, "file.Close: "+err.Error())
+	}
+
+	return nil
+}
+
+// parseLoudness returns the integrated loudness from the ebur128 summary, nil for silence or unknown output.
+func parseLoudness(stderr string) *float64 {
+	matches := integratedLoudness.FindAllStringSubmatch(stderr, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	// Итоговое значение печатается в сводке последним
+	value, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+	if err != nil || math.IsInf(value, 0) {
+		return nil
+	}
+
+	return &value
+}
+
+func lastLine(output string) string {
+	lines := bytes.Split(bytes.TrimSpace([]byte(output)), []byte("\n"))
+
+	return string(lines[len(lines)-1])
+}
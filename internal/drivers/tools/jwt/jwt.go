@@ -0,0 +1,115 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/errs"
+)
+
+const (
+	tokenParts = 3
+)
+
+// JWT issues and validates HS256-signed bearer tokens carrying the user ID in the "sub" claim.
+type JWT struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func New(cfg *config.Auth) (JWT, error) {
+	if cfg.Secret == "" {
+		return JWT{}, errs.New(errs.ErrInitialization, "jwt: empty secret")
+	}
+
+	return JWT{
+		secret: []byte(cfg.Secret),
+		ttl:    cfg.TokenTTL,
+	}, nil
+}
+
+func (j *JWT) IssueToken(userID int) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(j.ttl)
+
+	headerBytes, err := json.Marshal(Header{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", time.Time{}, errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	claimsBytes, err := json.Marshal(Claims{
+		Subject:   strconv.Itoa(userID),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	unsigned := encode(headerBytes) + "." + encode(claimsBytes)
+
+	return unsigned + "." + encode(j.sign(unsigned)), expiresAt, nil
+}
+
+func (j *JWT) ParseToken(token string) (int, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != tokenParts {
+		return 0, errs.New(errs.ErrUnauthorized, "malformed token")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return 0, errs.New(errs.ErrUnauthorized, "malformed token signature")
+	}
+
+	if !hmac.Equal(signature, j.sign(parts[0]+"."+parts[1])) {
+		return 0, errs.New(errs.ErrUnauthorized, "invalid token signature")
+	}
+
+	var header Header
+	if err := decode(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return 0, errs.New(errs.ErrUnauthorized, "unsupported token header")
+	}
+
+	var claims Claims
+	if err := decode(parts[1], &claims); err != nil {
+		return 0, errs.New(errs.ErrUnauthorized, "malformed token claims")
+	}
+
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return 0, errs.New(errs.ErrUnauthorized, "token expired")
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return 0, errs.New(errs.ErrUnauthorized, "invalid token subject")
+	}
+
+	return userID, nil
+}
+
+func (j *JWT) sign(unsigned string) []byte {
+	mac := hmac.New(sha256.New, j.secret)
+	mac.Write([]byte(unsigned))
+
+	return mac.Sum(nil)
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decode(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
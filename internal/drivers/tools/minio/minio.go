@@ -2,20 +2,37 @@ package minio
 
 import (
 	"context"
+	"errors"
+	"io"
 	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"speech-processing-service/internal/config"
 	"speech-processing-service/internal/errs"
 
+	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
 const (
 	urlExpirationTime = time.Second * 24 * 60 * 60 * 7
+
+	// sniffLen is how many bytes http.DetectContentType looks at
+	sniffLen = 512
 )
 
+// imageTypes are the accepted image formats, detected from the content rather than taken from the client
+var imageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
 type Minio struct {
 	client *minio.Client
 
@@ -61,15 +78,18 @@ func (m *Minio) GenerateUrl(ctx context.Context, imagePath string, isAnswer bool
 func (m *Minio) UploadAnswer(
 	ctx context.Context,
 	filename string,
-	file multipart.File,
-	size int64) error {
+	file io.Reader,
+	size int64,
+	contentType string) error {
 	_, err := m.client.PutObject(
 		ctx,
 		m.answersBucket,
 		filename,
 		file,
 		size,
-		minio.PutObjectOptions{},
+		minio.PutObjectOptions{
+			ContentType: contentType,
+		},
 	)
 	if err != nil {
 		return errs.New(errs.ErrMinio, "m.client.PutObject:"+err.Error())
@@ -77,19 +97,50 @@ func (m *Minio) UploadAnswer(
 	return nil
 }
 
+// DeleteAnswer removes an answer object; a missing object is not an error
+func (m *Minio) DeleteAnswer(ctx context.Context, filename string) error {
+	if err := m.client.RemoveObject(ctx, m.answersBucket, filename, minio.RemoveObjectOptions{}); err != nil {
+		return errs.New(errs.ErrMinio, "m.client.RemoveObject: "+err.Error())
+	}
+
+	return nil
+}
+
+// GetAnswer opens an answer object for reading and returns its content type
+func (m *Minio) GetAnswer(ctx context.Context, filename string) (io.ReadCloser, string, error) {
+	object, err := m.client.GetObject(ctx, m.answersBucket, filename, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, "", errs.New(errs.ErrMinio, "m.client.GetObject: "+err.Error())
+	}
+
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+
+		return nil, "", errs.New(errs.ErrMinio, "object.Stat: "+err.Error())
+	}
+
+	return object, info.ContentType, nil
+}
+
 // UploadFile uploads a file to images bucket and returns the path
 func (m *Minio) UploadFile(ctx context.Context, file *multipart.File, header *multipart.FileHeader, folder string) (string, error) {
-	// Генерируем путь к файлу
-	filename := folder + "/" + header.Filename
+	contentType, err := detectImageType(*file)
+	if err != nil {
+		return "", err
+	}
 
-	_, err := m.client.PutObject(
+	// Имя файла от клиента не используется: одинаковые имена перезаписывали бы чужие изображения
+	filename := folder + "/" + uuid.NewString() + strings.ToLower(filepath.Ext(header.Filename))
+
+	_, err = m.client.PutObject(
 		ctx,
 		m.imagesBucket,
 		filename,
 		*file,
 		header.Size,
 		minio.PutObjectOptions{
-			ContentType: header.Header.Get("Content-Type"),
+			ContentType: contentType,
 		},
 	)
 	if err != nil {
@@ -99,6 +150,37 @@ func (m *Minio) UploadFile(ctx context.Context, file *multipart.File, header *mu
 	return filename, nil
 }
 
+// detectImageType sniffs the content type of the file and rewinds it. Files that are not images are rejected,
+// so the bucket never serves arbitrary content under an image URL.
+func detectImageType(file multipart.File) (string, error) {
+	head := make([]byte, sniffLen)
+
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", errs.New(errs.ErrInvalidArgument, "image: "+err.Error())
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", errs.New(errs.ErrInvalidArgument, "image: "+err.Error())
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	if !imageTypes[contentType] {
+		return "", errs.New(errs.ErrInvalidArgument, "unsupported image type: "+contentType)
+	}
+
+	return contentType, nil
+}
+
+// DeleteFile removes a file from images bucket; a missing object is not an error
+func (m *Minio) DeleteFile(ctx context.Context, filename string) error {
+	if err := m.client.RemoveObject(ctx, m.imagesBucket, filename, minio.RemoveObjectOptions{}); err != nil {
+		return errs.New(errs.ErrMinio, "m.client.RemoveObject: "+err.Error())
+	}
+
+	return nil
+}
+
 // GenerateURL generates a presigned URL for a file in images bucket
 func (m *Minio) GenerateURL(ctx context.Context, filename string) (string, error) {
 	return m.GenerateUrl(ctx, filename, false)
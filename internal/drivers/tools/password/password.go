@@ -0,0 +1,41 @@
+package password
+
+import (
+	"errors"
+
+	"speech-processing-service/internal/errs"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Hasher struct {
+	cost int
+}
+
+func New() Hasher {
+	return Hasher{
+		cost: bcrypt.DefaultCost,
+	}
+}
+
+func (h *Hasher) HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", errs.New(errs.ErrUseCaseExecution, "bcrypt.GenerateFromPassword: "+err.Error())
+	}
+
+	return string(hash), nil
+}
+
+func (h *Hasher) ComparePassword(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return errs.New(errs.ErrUnauthorized, "invalid email or password")
+	}
+
+	if err != nil {
+		return errs.New(errs.ErrUseCaseExecution, "bcrypt.CompareHashAndPassword: "+err.Error())
+	}
+
+	return nil
+}
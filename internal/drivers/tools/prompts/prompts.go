@@ -0,0 +1,243 @@
+package prompts
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+const (
+	extension = ".tmpl"
+
+	// headerDelimiter opens and closes the header at the top of a template
+	headerDelimiter = "---\n"
+	capabilitiesKey = "capabilities:"
+
+	// capabilityLanguage marks versions that write explanations in the requested feedback language
+	capabilityLanguage = "feedback_language"
+	// capabilityCategory marks versions that ask for the category of grammar issues
+	capabilityCategory = "grammar_category"
+)
+
+// versionFile matches template file names, e.g. "v2.tmpl".
+var versionFile = regexp.MustCompile(`^v([0-9]+)\.tmpl$`)
+
+// Prompts holds the prompt templates loaded at startup from {dir}/{name}/v{N}.tmpl.
+// New versions are added as new files, so the stored analyses keep pointing to the text they were made with.
+// Every template starts with a header declaring what the version supports, e.g.
+//
+//	---
+//	capabilities: feedback_language, grammar_category
+//	---
+type Prompts struct {
+	templates map[string]map[string]*template.Template
+	// latest is the highest version number of every prompt
+	latest map[string]string
+	// capabilities are the declared capabilities of every version, keyed by "name/version"
+	capabilities map[string]capabilities
+}
+
+type capabilities struct {
+	localized   bool
+	categorized bool
+}
+
+func New(cfg *config.Prompts) (Prompts, error) {
+	names, err := os.ReadDir(cfg.Dir)
+	if err != nil {
+		return Prompts{}, errs.New(errs.ErrInitialization, "prompts: "+err.Error())
+	}
+
+	p := Prompts{
+		templates:    make(map[string]map[string]*template.Template),
+		latest:       make(map[string]string),
+		capabilities: make(map[string]capabilities),
+	}
+
+	for _, name := range names {
+		if !name.IsDir() {
+			continue
+		}
+
+		if err := p.loadPrompt(filepath.Join(cfg.Dir, name.Name()), name.Name()); err != nil {
+			return Prompts{}, err
+		}
+	}
+
+	return p, nil
+}
+
+func (p *Prompts) loadPrompt(dir, name string) error {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return errs.New(errs.ErrInitialization, "prompts: "+err.Error())
+	}
+
+	latest := -1
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), extension) {
+			continue
+		}
+
+		match := versionFile.FindStringSubmatch(file.Name())
+		if match == nil {
+			return errs.New(errs.ErrInitialization, "prompts: file name must be v{N}.tmpl: "+filepath.Join(dir, file.Name()))
+		}
+
+		text, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return errs.New(errs.ErrInitialization, "prompts: "+err.Error())
+		}
+
+		version := strings.TrimSuffix(file.Name(), extension)
+
+		caps, body, err := parseHeader(string(text))
+		if err != nil {
+			return errs.New(errs.ErrInitialization, "prompts: "+filepath.Join(dir, file.Name())+": "+err.Error())
+		}
+
+		tmpl, err := parse(name+"/"+version, body)
+		if err != nil {
+			return errs.New(errs.ErrInitialization, "prompts: "+err.Error())
+		}
+
+		if p.templates[name] == nil {
+			p.templates[name] = make(map[string]*template.Template)
+		}
+		p.templates[name][version] = tmpl
+		p.capabilities[name+"/"+version] = caps
+
+		// Версии сравниваются как числа: v10 новее v9
+		number, _ := strconv.Atoi(match[1])
+		if number > latest {
+			latest = number
+			p.latest[name] = version
+		}
+	}
+
+	return nil
+}
+
+// Resolve checks that the prompt exists and fills in the latest version when it is not set.
+func (p *Prompts) Resolve(prompt entity.PromptVersion) (entity.PromptVersion, error) {
+	versions, ok := p.templates[prompt.Name]
+	if !ok {
+		return entity.PromptVersion{}, errs.New(errs.ErrNotFound, "prompt not found: "+prompt.Name)
+	}
+
+	if prompt.Version == "" {
+		prompt.Version = p.latest[prompt.Name]
+	}
+
+	if _, ok := versions[prompt.Version]; !ok {
+		return entity.PromptVersion{}, errs.New(errs.ErrNotFound, "prompt version not found: "+prompt.Name+"/"+prompt.Version)
+	}
+
+	return prompt, nil
+}
+
+// Localized reports whether the prompt version uses the feedback language; older versions always produce English.
+func (p *Prompts) Localized(prompt entity.PromptVersion) bool {
+	return p.capabilities[prompt.Name+"/"+prompt.Version].localized
+}
+
+// Categorized reports whether the prompt version asks the model for the category of grammar issues.
+func (p *Prompts) Categorized(prompt entity.PromptVersion) bool {
+	return p.capabilities[prompt.Name+"/"+prompt.Version].categorized
+}
+
+// Render executes the stored template of the resolved prompt.
+func (p *Prompts) Render(prompt entity.PromptVersion, data any) (string, error) {
+	tmpl, ok := p.templates[prompt.Name][prompt.Version]
+	if !ok {
+		return "", errs.New(errs.ErrNotFound, "prompt version not found: "+prompt.Name+"/"+prompt.Version)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", errs.New(errs.ErrUseCaseExecution, "tmpl.Execute: "+err.Error())
+	}
+
+	return rendered.String(), nil
+}
+
+// RenderDraft executes a template that is not stored yet. The header is optional in a draft;
+// errors in the draft are client errors.
+func (p *Prompts) RenderDraft(text string, data any) (string, error) {
+	if strings.HasPrefix(text, headerDelimiter) {
+		_, body, err := parseHeader(text)
+		if err != nil {
+			return "", errs.New(errs.ErrDecodingJSON, "template: "+err.Error())
+		}
+		text = body
+	}
+
+	tmpl, err := parse("draft", text)
+	if err != nil {
+		return "", errs.New(errs.ErrDecodingJSON, "template: "+err.Error())
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", errs.New(errs.ErrDecodingJSON, "template: "+err.Error())
+	}
+
+	return rendered.String(), nil
+}
+
+func parse(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(text)
+}
+
+// parseHeader splits the template into the capabilities declared in its header and the template text.
+// The header is required, so a new version can't be added without stating what it supports.
+func parseHeader(text string) (capabilities, string, error) {
+	rest, ok := strings.CutPrefix(text, headerDelimiter)
+	if !ok {
+		return capabilities{}, "", errors.New("template must start with a --- header")
+	}
+
+	header, body, ok := strings.Cut(rest, "\n"+headerDelimiter)
+	if !ok {
+		return capabilities{}, "", errors.New("header is not closed with ---")
+	}
+
+	var (
+		caps     capabilities
+		declared bool
+	)
+
+	for _, line := range strings.Split(header, "\n") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), capabilitiesKey)
+		if !ok {
+			return capabilities{}, "", errors.New("unknown header line: " + line)
+		}
+		declared = true
+
+		for _, capability := range strings.Split(value, ",") {
+			switch strings.TrimSpace(capability) {
+			case "":
+			case capabilityLanguage:
+				caps.localized = true
+			case capabilityCategory:
+				caps.categorized = true
+			default:
+				return capabilities{}, "", errors.New("unknown capability: " + strings.TrimSpace(capability))
+			}
+		}
+	}
+
+	if !declared {
+		return capabilities{}, "", errors.New("header must declare the capabilities")
+	}
+
+	return caps, body, nil
+}
@@ -0,0 +1,94 @@
+package whisper
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/drivers/tools/audio"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+type AudioReader interface {
+	GetAnswer(ctx context.Context, filename string) (io.ReadCloser, string, error)
+}
+
+// Whisper transcribes audio offline by running a whisper.cpp-compatible binary on the stored bytes.
+type Whisper struct {
+	cfg    *config.STT
+	reader AudioReader
+}
+
+func New(cfg *config.STT, reader AudioReader) (Whisper, error) {
+	if cfg.WhisperBinary == "" || cfg.WhisperModel == "" {
+		return Whisper{}, errs.New(errs.ErrInitialization, "whisper: binary and model are required")
+	}
+
+	if _, err := exec.LookPath(cfg.WhisperBinary); err != nil {
+		return Whisper{}, errs.New(errs.ErrInitialization, "whisper: "+err.Error())
+	}
+
+	return Whisper{
+		cfg:    cfg,
+		reader: reader,
+	}, nil
+}
+
+// TranscribeAudio accepts only normalized wav recordings: whisper.cpp can't decode compressed formats.
+func (w *Whisper) TranscribeAudio(ctx context.Context, source entity.AudioSource) (entity.Transcription, error) {
+	if path.Ext(source.ObjectName) != "."+audio.FormatWAV.Extension {
+		return entity.Transcription{}, errs.New(errs.ErrInvalidArgument, "whisper: only wav recordings are supported, got "+source.ObjectName)
+	}
+
+	object, _, err := w.reader.GetAnswer(ctx, source.ObjectName)
+	if err != nil {
+		return entity.Transcription{}, errs.Wrap("w.reader.GetAnswer", err)
+	}
+	defer object.Close()
+
+	// whisper.cpp читает только файлы, поэтому сохраняем запись во временный файл
+	tmp, err := os.CreateTemp("", "answer-*"+path.Ext(source.ObjectName))
+	if err != nil {
+		return entity.Transcription{}, errs.New(errs.ErrExecutionRequest, "os.CreateTemp: "+err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, object); err != nil {
+		tmp.Close()
+
+		return entity.Transcription{}, errs.New(errs.ErrExecutionRequest, "io.Copy: "+err.Error())
+	}
+
+	if err := tmp.Close(); err != nil {
+		return entity.Transcription{}, errs.New(errs.ErrExecutionRequest, "tmp.Close: "+err.Error())
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(
+		ctx,
+		w.cfg.WhisperBinary,
+		"-m", w.cfg.WhisperModel,
+		"-l", w.cfg.WhisperLanguage,
+		"-f", tmp.Name(),
+		"-nt", // без временных меток
+		"-np", // только результат, без служебного вывода
+	)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return entity.Transcription{}, errs.New(errs.ErrExecutionRequest, "whisper: "+err.Error()+": "+stderr.String())
+	}
+
+	// Без временных меток метрики беглости для whisper не считаются
+	return entity.Transcription{
+		Transcript: strings.Join(strings.Fields(stdout.String()), " "),
+	}, nil
+}
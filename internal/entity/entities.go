@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type Topic struct {
 	ID          int
 	Title       string
@@ -13,21 +15,198 @@ type Question struct {
 	Text string
 }
 
+const (
+	SessionStatusInProgress = "in_progress"
+	SessionStatusCompleted  = "completed"
+)
+
 type Session struct {
 	ID        string
 	TopicID   int
 	Questions []Question
 }
 
+type SessionSummary struct {
+	ID           string
+	TopicID      int
+	TopicTitle   string
+	Status       string
+	OverallLevel *string
+	AnswersCount int
+	CreatedAt    string
+	CompletedAt  *string
+}
+
+type SessionAnswer struct {
+	ID           int
+	QuestionID   int
+	QuestionText string
+	AudioURL     string
+	Transcript   *string
+}
+
+type SessionDetail struct {
+	ID          string
+	TopicID     int
+	TopicTitle  string
+	Status      string
+	Answers     []SessionAnswer
+	Analysis    *AnalyzeTextResult
+	CreatedAt   string
+	CompletedAt *string
+}
+
+// AudioSource points to a stored answer recording. Providers that fetch audio themselves use URL,
+// local engines read the object by name.
+type AudioSource struct {
+	ObjectName string
+	URL        string
+}
+
+// AnswerFile describes a validated answer recording stored in the answers bucket.
+type AnswerFile struct {
+	ObjectName  string
+	ContentType string
+	Size        int64
+	Checksum    string // hex-encoded SHA-256
+	// Duration is nil when the container does not store it in the header
+	Duration *time.Duration
+
+	// Normalized is set when the server-side normalization is enabled
+	Normalized *NormalizedFile
+}
+
+// NormalizedFile is the 16kHz mono copy of an answer with trimmed silence.
+type NormalizedFile struct {
+	ObjectName string
+	Duration   time.Duration
+	Loudness   *float64 // integrated loudness, LUFS; nil when it can't be measured
+}
+
+// NormalizedAudio is the normalization output before it is stored.
+type NormalizedAudio struct {
+	Data        []byte
+	ContentType string
+	Extension   string
+	Duration    time.Duration
+	Loudness    *float64
+}
+
+type TranscribedWord struct {
+	Word       string
+	Start      float64 // seconds from the beginning of the recording
+	End        float64
+	Confidence float64 // 0..1
+}
+
+// Transcription is the provider output; Words is empty when the provider has no word timings.
+type Transcription struct {
+	Transcript string
+	Words      []TranscribedWord
+}
+
+// TranscriptUpdate is a streaming recognition result. Interim results are replaced by later ones,
+// final results are not revised and together make the whole transcript.
+type TranscriptUpdate struct {
+	Transcript string
+	Words      []TranscribedWord
+	IsFinal    bool
+}
+
+type LowConfidenceWord struct {
+	Word       string  `json:"word"`
+	Confidence float64 `json:"confidence"`
+	Start      float64 `json:"start"`
+}
+
+// AnswerFluency holds speaking-fluency metrics of a single answer.
+type AnswerFluency struct {
+	AnswerID            int                 `json:"answer_id"`
+	QuestionID          int                 `json:"question_id"`
+	WordsCount          int                 `json:"words_count"`
+	SpeakingSeconds     float64             `json:"speaking_seconds"`
+	WordsPerMinute      float64             `json:"words_per_minute"`
+	PauseCount          int                 `json:"pause_count"`
+	TotalPauseSeconds   float64             `json:"total_pause_seconds"`
+	LongestPauseSeconds float64             `json:"longest_pause_seconds"`
+	FillerCount         int                 `json:"filler_count"`
+	FillerRate          float64             `json:"filler_rate"` // fillers per word
+	LowConfidenceWords  []LowConfidenceWord `json:"low_confidence_words"`
+}
+
+const DefaultFeedbackLanguage = "en"
+
+// FeedbackLanguages maps the supported feedback language codes to the language names used in prompts.
+var FeedbackLanguages = map[string]string{
+	"en": "English",
+	"ru": "Russian",
+	"be": "Belarusian",
+}
+
+type UserSettings struct {
+	FeedbackLanguage string
+	// TimeZone is an IANA name; study days and streaks are counted in it
+	TimeZone string
+}
+
+// UserSettingsUpdate changes the settings that are not nil.
+type UserSettingsUpdate struct {
+	FeedbackLanguage *string
+	TimeZone         *string
+}
+
+var CEFRLevels = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
+func IsCEFRLevel(level string) bool {
+	for _, l := range CEFRLevels {
+		if l == level {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GrammarCategories are the rule categories grammar issues are tagged with, the same as in the enum of GrammarIssue.
+var GrammarCategories = []string{
+	"articles", "tenses", "prepositions", "subject_verb_agreement", "word_order", "plurals", "pronouns",
+	"modal_verbs", "conditionals", "passive_voice", "verb_forms", "comparatives", "questions", "negation", "other",
+}
+
+func IsGrammarCategory(category string) bool {
+	for _, c := range GrammarCategories {
+		if c == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CEFRScore maps a level to 1 (A1) .. 6 (C2), 0 for anything else.
+func CEFRScore(level string) int {
+	for i, l := range CEFRLevels {
+		if l == level {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
 type TopWord struct {
 	Words string `json:"words"`
-	Level string `json:"level"`
+	Level string `json:"level" enum:"A1,A2,B1,B2,C1,C2"`
 }
 
 type GrammarIssue struct {
 	Sentence          string `json:"sentence"`
 	Explanation       string `json:"explanation"`
 	CorrectedSentence string `json:"corrected_sentence"`
+	Category          string `json:"category,omitempty" enum:"articles,tenses,prepositions,subject_verb_agreement,word_order,plurals,pronouns,modal_verbs,conditionals,passive_voice,verb_forms,comparatives,questions,negation,other"`
+
+	// AnswerID links the issue to the answer it was found in
+	AnswerID int `json:"answer_id,omitempty" schema:"-"`
 }
 
 type RephraseSuggestion struct {
@@ -35,12 +214,78 @@ type RephraseSuggestion struct {
 	Suggestion string `json:"suggestion"`
 }
 
+const (
+	RelevanceRelevant = "relevant"
+	RelevancePartial  = "partially_relevant"
+	RelevanceOffTopic = "off_topic"
+)
+
+// AnswerFeedback is the analysis of a single answer. The model fills the assessment,
+// the question and the transcript are added from the session.
+type AnswerFeedback struct {
+	AnswerID      int            `json:"answer_id"`
+	Relevance     string         `json:"relevance" enum:"relevant,partially_relevant,off_topic"`
+	Level         string         `json:"level" enum:"A1,A2,B1,B2,C1,C2"`
+	GrammarIssues []GrammarIssue `json:"grammar_issues"`
+	Feedback      string         `json:"feedback"`
+
+	QuestionID int    `json:"question_id" schema:"-"`
+	Question   string `json:"question" schema:"-"`
+	Transcript string `json:"transcript" schema:"-"`
+}
+
 type AnalyzeTextResult struct {
-	OverallLevel        string               `json:"overall_level"`
+	OverallLevel        string               `json:"overall_level" enum:"A1,A2,B1,B2,C1,C2"`
 	TopWords            []TopWord            `json:"top_words"`
-	GrammarIssues       []GrammarIssue       `json:"grammar_issues"`
 	RephraseSuggestions []RephraseSuggestion `json:"rephrase_suggestions"`
 	OverallFeedback     string               `json:"overall_feedback"`
+	Answers             []AnswerFeedback     `json:"answers"`
+
+	// GrammarIssues collects the issues of all answers
+	GrammarIssues []GrammarIssue `json:"grammar_issues" schema:"-"`
+	// Prompt is the template the analysis was produced with
+	Prompt *PromptVersion `json:"prompt,omitempty" schema:"-"`
+	// FeedbackLanguage is the language code of the explanations; empty means English
+	FeedbackLanguage string `json:"feedback_language,omitempty" schema:"-"`
+
+	// Fluency is computed from word timings, not by the model
+	Fluency []AnswerFluency `json:"fluency,omitempty" schema:"-"`
+}
+
+// DefaultAnalysisPrompt is the session analysis prompt used when the request doesn't name one.
+const DefaultAnalysisPrompt = "session_analysis"
+
+// PromptVersion identifies a prompt template. An empty Name selects the default prompt,
+// an empty Version the latest version of the prompt.
+type PromptVersion struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// AnalysisPromptData is what the session analysis templates are rendered with.
+type AnalysisPromptData struct {
+	Answers []PromptAnswer
+	// FeedbackLanguage is the language name for the explanations, e.g. "Russian"
+	FeedbackLanguage string
+}
+
+func (d AnalysisPromptData) QuestionsCount() int {
+	return len(d.Answers)
+}
+
+type PromptAnswer struct {
+	ID         int
+	Question   string
+	Transcript string
+}
+
+// PromptPreview is a request to render a stored prompt or, when Template is set, a draft of it.
+type PromptPreview struct {
+	Prompt   PromptVersion
+	Template string
+	Data     AnalysisPromptData
+	// FeedbackLanguage is the language code; empty means English
+	FeedbackLanguage string
 }
 
 type ArticlePreview struct {
@@ -97,6 +342,34 @@ type AISuggestion struct {
 	Reason      string `json:"reason"`
 }
 
+// WordSuggestionsPrompt is the prompt that asks the model for new words of a collection.
+const WordSuggestionsPrompt = "word_suggestions"
+
+// WordSuggestionsResult is the reply of the model to the word suggestions prompt.
+type WordSuggestionsResult struct {
+	Suggestions []AISuggestion `json:"suggestions"`
+}
+
+type SuggestionPromptData struct {
+	CollectionName string
+	Words          []PromptWord
+	Count          int
+	// TranslationLanguage is the name of the learner's feedback language, used when there are no words to follow
+	TranslationLanguage string
+}
+
+type PromptWord struct {
+	Word        string
+	Translation string
+}
+
+// WordSuggestions are the cached suggestions of a collection, regenerated when they are older than the TTL.
+type WordSuggestions struct {
+	CollectionID string
+	Suggestions  []AISuggestion
+	GeneratedAt  string
+}
+
 type UserWord struct {
 	ID             string
 	CollectionID   string
@@ -105,10 +378,44 @@ type UserWord struct {
 	Example        *string
 	NextReviewDate string
 	ReviewCount    int
+	Schedule       ReviewSchedule
+	LastReviewedAt *string
+	LearnedAt      *string
 	CreatedAt      string
 	UpdatedAt      string
 }
 
+// UserWordUpdate changes the fields that are not nil; an empty Example removes the example.
+type UserWordUpdate struct {
+	Word        *string
+	Translation *string
+	Example     *string
+}
+
+// ReviewSchedule is the SM-2 state of a word. Repetitions counts the successful reviews in a row
+// and is reset by a lapse, while UserWord.ReviewCount counts all reviews.
+type ReviewSchedule struct {
+	EaseFactor   float64
+	IntervalDays int
+	Repetitions  int
+	Lapses       int
+}
+
+// WordReview is a recall of a word and the schedule that follows from it. Learned is whether the new
+// interval reaches the mastery threshold; the word loses the mark after a lapse.
+type WordReview struct {
+	Grade    int
+	Schedule ReviewSchedule
+	Learned  bool
+}
+
+// ReviewQueue is the words of a collection due for review, the longest overdue first.
+type ReviewQueue struct {
+	CollectionID string
+	DueCount     int
+	Words        []UserWord
+}
+
 type WordCollectionDetail struct {
 	ID                string
 	Name              string
@@ -123,3 +430,167 @@ type WordCollectionDetail struct {
 	CreatedAt         string
 	UpdatedAt         string
 }
+
+type User struct {
+	ID        int
+	Email     string
+	CreatedAt string
+}
+
+type AuthToken struct {
+	Token     string
+	ExpiresAt string
+	User      User
+}
+
+const (
+	JobTypeCompleteSession = "complete_session"
+
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+)
+
+type Job struct {
+	ID          string
+	Type        string
+	UserID      *int
+	Payload     []byte
+	Status      string
+	Progress    int
+	Result      []byte
+	Attempts    int
+	MaxAttempts int
+	LastError   *string
+	RunAt       string
+	CreatedAt   string
+	UpdatedAt   string
+	FinishedAt  *string
+
+	SessionAnalysis *AnalyzeTextResult
+}
+
+type CompleteSessionPayload struct {
+	SessionID string        `json:"session_id"`
+	UserID    int           `json:"user_id"`
+	Reanalyze bool          `json:"reanalyze"`
+	Prompt    PromptVersion `json:"prompt"`
+	// FeedbackLanguage overrides the user setting when set
+	FeedbackLanguage string `json:"feedback_language,omitempty"`
+}
+
+const (
+	ReadAloudCorrect     = "correct"
+	ReadAloudSubstituted = "substituted"
+	ReadAloudOmitted     = "omitted"
+	ReadAloudInserted    = "inserted"
+)
+
+// ReadAloudSource selects the reference text: the text itself, an example of the learner's word or
+// a sentence of an article. Exactly one of Text, WordID and ArticleID is set.
+type ReadAloudSource struct {
+	Text      string
+	WordID    string
+	ArticleID int
+	// Sentence is the zero-based sentence index in the article
+	Sentence int
+}
+
+// ReadAloudWord is a step of the alignment: a reference word with what was heard instead of it,
+// or an inserted word with an empty Reference.
+type ReadAloudWord struct {
+	Reference string
+	Spoken    string
+	Status    string
+	// Accuracy is 0..100, insertions have none
+	Accuracy float64
+	Start    float64
+	End      float64
+}
+
+type ReadAloudResult struct {
+	Reference     string
+	Transcript    string
+	Accuracy      float64
+	Words         []ReadAloudWord
+	Substitutions int
+	Omissions     int
+	Insertions    int
+}
+
+// SessionMetrics are the numbers of a completed session kept for progress tracking.
+type SessionMetrics struct {
+	OverallLevel  string
+	AnswersCount  int
+	GrammarIssues int
+	// Vocabulary counts the top words by CEFR level
+	Vocabulary map[string]int
+	// WordsPerMinute and FillerRate are averaged over the answers with word timings, nil without them
+	WordsPerMinute *float64
+	FillerRate     *float64
+}
+
+const (
+	SkillLevel      = "level"
+	SkillGrammar    = "grammar"
+	SkillVocabulary = "vocabulary"
+	SkillFluency    = "fluency"
+	SkillFillers    = "fillers"
+
+	TrendImproving        = "improving"
+	TrendDeclining        = "declining"
+	TrendStable           = "stable"
+	TrendInsufficientData = "insufficient_data"
+)
+
+// ProgressPoint is a completed session on the progress time series.
+type ProgressPoint struct {
+	SessionID   string
+	CompletedAt string
+	SessionMetrics
+
+	LevelScore int
+	// GrammarIssuesPerAnswer makes sessions with a different number of questions comparable
+	GrammarIssuesPerAnswer float64
+	// VocabularyScore is the mean CEFR score of the top words, nil without them
+	VocabularyScore *float64
+}
+
+// SkillTrend compares the recent sessions with the ones before them.
+type SkillTrend struct {
+	Skill     string
+	Recent    *float64
+	Previous  *float64
+	Change    *float64
+	Direction string
+}
+
+type UserProgress struct {
+	// CurrentLevel is estimated over the recent sessions, nil without completed sessions
+	CurrentLevel *string
+	CurrentScore *float64
+	Series       []ProgressPoint
+	Trends       []SkillTrend
+}
+
+// GrammarMistake is a stored grammar issue of the learner.
+type GrammarMistake struct {
+	SessionID         string
+	AnswerID          *int
+	Sentence          string
+	CorrectedSentence string
+	Explanation       string
+	CreatedAt         string
+}
+
+// GrammarMistakeCategory is a rule category the learner breaks, with the latest examples and
+// the articles that explain the rule.
+type GrammarMistakeCategory struct {
+	Category      string
+	Count         int
+	SessionsCount int
+	LastSeenAt    string
+	Examples      []GrammarMistake
+	Articles      []ArticlePreview
+}
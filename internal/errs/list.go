@@ -12,12 +12,24 @@ var (
 	ErrMarshalingJSON       = errors.New("marshaling json error")
 	ErrExecutionRequest     = errors.New("request execution error")
 	ErrUnexpectedStatusCode = errors.New("unexpected status code")
+	ErrInvalidModelReply    = errors.New("invalid model reply")
 
 	//Bad request errors
 	ErrTypeMustBeNumeric = errors.New("type must be numeric")
 	ErrTypeMustBeUUID    = errors.New("type must be uuid")
 	ErrDecodingJSON      = errors.New("decoding json error")
+	ErrInvalidAudio      = errors.New("invalid audio")
+	ErrInvalidArgument   = errors.New("invalid argument")
 
 	//Not found errors
 	ErrNotFound = errors.New("not found")
+
+	//Unauthorized errors
+	ErrUnauthorized = errors.New("unauthorized")
+
+	//Conflict errors
+	ErrAlreadyExists    = errors.New("already exists")
+	ErrSessionCompleted = errors.New("session is completed")
+	ErrReviewConflict   = errors.New("word was reviewed concurrently")
+	ErrAnswerReplaced   = errors.New("answer was re-recorded")
 )
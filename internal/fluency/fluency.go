@@ -0,0 +1,94 @@
+package fluency
+
+import (
+	"math"
+	"strings"
+
+	"speech-processing-service/internal/entity"
+)
+
+const (
+	// pauseThreshold is the minimal silence between words, in seconds, counted as a pause.
+	pauseThreshold = 0.5
+	// lowConfidenceThreshold marks words the recognizer was unsure about, usually mispronounced ones.
+	lowConfidenceThreshold = 0.6
+)
+
+var fillerWords = map[string]struct{}{
+	"uh":  {},
+	"um":  {},
+	"uhm": {},
+	"umm": {},
+	"er":  {},
+	"erm": {},
+	"ah":  {},
+	"hmm": {},
+	"mm":  {},
+}
+
+// IsFiller reports whether the recognized word is a hesitation sound like "um".
+func IsFiller(word string) bool {
+	_, ok := fillerWords[strings.ToLower(strings.Trim(word, ".,!?;:\""))]
+
+	return ok
+}
+
+// Compute derives speaking metrics from word timings. It returns nil when the provider gave
+// no timings.
+func Compute(answerID, questionID int, words []entity.TranscribedWord) *entity.AnswerFluency {
+	if len(words) == 0 {
+		return nil
+	}
+
+	fluency := entity.AnswerFluency{
+		AnswerID:           answerID,
+		QuestionID:         questionID,
+		WordsCount:         len(words),
+		LowConfidenceWords: []entity.LowConfidenceWord{},
+	}
+
+	for i, word := range words {
+		if IsFiller(word.Word) {
+			fluency.FillerCount++
+		}
+
+		if word.Confidence < lowConfidenceThreshold {
+			fluency.LowConfidenceWords = append(fluency.LowConfidenceWords, entity.LowConfidenceWord{
+				Word:       word.Word,
+				Confidence: word.Confidence,
+				Start:      word.Start,
+			})
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		gap := word.Start - words[i-1].End
+		if gap >= pauseThreshold {
+			fluency.PauseCount++
+			fluency.TotalPauseSeconds += gap
+			fluency.LongestPauseSeconds = max(fluency.LongestPauseSeconds, gap)
+		}
+	}
+
+	// Темп считаем от первого до последнего слова, тишина по краям записи не учитывается
+	fluency.SpeakingSeconds = words[len(words)-1].End - words[0].Start
+	if fluency.SpeakingSeconds > 0 {
+		fluency.WordsPerMinute = float64(len(words)) / fluency.SpeakingSeconds * 60
+	}
+
+	fluency.FillerRate = float64(fluency.FillerCount) / float64(len(words))
+
+	fluency.SpeakingSeconds = round(fluency.SpeakingSeconds)
+	fluency.WordsPerMinute = round(fluency.WordsPerMinute)
+	fluency.TotalPauseSeconds = round(fluency.TotalPauseSeconds)
+	fluency.LongestPauseSeconds = round(fluency.LongestPauseSeconds)
+	fluency.FillerRate = round(fluency.FillerRate)
+
+	return &fluency
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
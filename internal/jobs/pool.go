@@ -0,0 +1,207 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+
+	"go.uber.org/zap"
+)
+
+const (
+	maxRetryBackoff = time.Hour
+)
+
+// ProgressFunc reports job progress in percent (0-100).
+type ProgressFunc func(percent int)
+
+// Handler executes a job of a single type and returns its JSON result.
+type Handler interface {
+	Handle(ctx context.Context, job entity.Job, progress ProgressFunc) ([]byte, error)
+}
+
+type Queue interface {
+	ClaimJob(ctx context.Context, lease time.Duration) (storage.Job, error)
+	UpdateJobProgress(ctx context.Context, jobID string, attempt, progress int) error
+	SucceedJob(ctx context.Context, jobID string, attempt int, result []byte) error
+	RetryJob(ctx context.Context, jobID string, attempt int, lastError string, runAt time.Time) error
+	FailJob(ctx context.Context, jobID string, attempt int, lastError string) error
+}
+
+// Pool runs worker goroutines that poll the Postgres-backed queue.
+type Pool struct {
+	logger *zap.Logger
+	cfg    *config.Jobs
+
+	queue    Queue
+	handlers map[string]Handler
+
+	wg sync.WaitGroup
+}
+
+func New(logger *zap.Logger, queue Queue, cfg *config.Jobs) Pool {
+	return Pool{
+		logger:   logger,
+		cfg:      cfg,
+		queue:    queue,
+		handlers: make(map[string]Handler),
+	}
+}
+
+func (p *Pool) Register(jobType string, handler Handler) {
+	p.handlers[jobType] = handler
+}
+
+// Start launches the workers; they stop when ctx is cancelled.
+func (p *Pool) Start(ctx context.Context) {
+	p.logger.Info("job workers start", zap.Int("workers", p.cfg.Workers))
+
+	for i := 0; i < p.cfg.Workers; i++ {
+		p.wg.Add(1)
+
+		go func() {
+			defer p.wg.Done()
+			p.work(ctx)
+		}()
+	}
+}
+
+// Wait blocks until all workers have finished their current jobs.
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
+
+func (p *Pool) work(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Разбираем очередь до конца, затем ждём следующего тика
+		for p.runNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNext claims and executes one job. It returns false when there is nothing to do.
+func (p *Pool) runNext(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	dbJob, err := p.queue.ClaimJob(ctx, p.cfg.Lease)
+	if err != nil {
+		if !errors.Is(err, errs.ErrNotFound) {
+			p.logger.Error("p.queue.ClaimJob", zap.Error(err))
+		}
+
+		return false
+	}
+
+	job := toEntity(dbJob)
+	logger := p.logger.With(zap.String("job_id", job.ID), zap.String("type", job.Type), zap.Int("attempt", job.Attempts))
+
+	handler, ok := p.handlers[job.Type]
+	if !ok {
+		p.finish(logger, job, nil, errs.New(errs.ErrNotFound, "no handler for job type "+job.Type))
+
+		return true
+	}
+
+	// Задача не должна пережить аренду, иначе её подхватит другой воркер
+	jobCtx, cancel := context.WithTimeout(ctx, p.cfg.Lease)
+	defer cancel()
+
+	progress := func(percent int) {
+		if err := p.queue.UpdateJobProgress(jobCtx, job.ID, job.Attempts, percent); err != nil {
+			logger.Error("p.queue.UpdateJobProgress", zap.Error(err))
+		}
+	}
+
+	result, err := handler.Handle(jobCtx, job, progress)
+	p.finish(logger, job, result, err)
+
+	return true
+}
+
+func (p *Pool) finish(logger *zap.Logger, job entity.Job, result []byte, jobErr error) {
+	// Статус пишем даже при остановке сервиса, иначе задача повиснет до конца аренды
+	ctx := context.Background()
+
+	if jobErr == nil {
+		if err := p.queue.SucceedJob(ctx, job.ID, job.Attempts, result); err != nil {
+			logger.Error("p.queue.SucceedJob", zap.Error(err))
+
+			return
+		}
+
+		logger.Info("job succeeded")
+
+		return
+	}
+
+	if job.Attempts >= job.MaxAttempts || !isRetryable(jobErr) {
+		if err := p.queue.FailJob(ctx, job.ID, job.Attempts, jobErr.Error()); err != nil {
+			logger.Error("p.queue.FailJob", zap.Error(err))
+		}
+
+		logger.Error("job failed", zap.Error(jobErr))
+
+		return
+	}
+
+	runAt := time.Now().Add(p.backoff(job.Attempts))
+	if err := p.queue.RetryJob(ctx, job.ID, job.Attempts, jobErr.Error(), runAt); err != nil {
+		logger.Error("p.queue.RetryJob", zap.Error(err))
+	}
+
+	logger.Warn("job will be retried", zap.Error(jobErr), zap.Time("run_at", runAt))
+}
+
+// backoff doubles the base delay on every attempt.
+func (p *Pool) backoff(attempt int) time.Duration {
+	delay := p.cfg.RetryBackoff
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryBackoff)
+}
+
+// isRetryable reports whether another attempt can succeed: missing data will not appear by itself
+// and an invalid payload stays invalid. A model that kept replying invalidly after re-prompting is not asked
+// again, every attempt is billed.
+func isRetryable(err error) bool {
+	return !errors.Is(err, errs.ErrNotFound) && !errors.Is(err, errs.ErrUnauthorized) &&
+		!errors.Is(err, errs.ErrInvalidArgument) && !errors.Is(err, errs.ErrInvalidModelReply)
+}
+
+func toEntity(job storage.Job) entity.Job {
+	return entity.Job{
+		ID:          job.ID,
+		Type:        job.Type,
+		UserID:      job.UserID,
+		Payload:     job.Payload,
+		Status:      job.Status,
+		Progress:    job.Progress,
+		Result:      job.Result,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LastError:   job.LastError,
+		RunAt:       job.RunAt,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+}
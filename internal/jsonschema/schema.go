@@ -0,0 +1,93 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+// Schema is the subset of JSON Schema understood by both Gemini responseSchema and OpenAI json_schema.
+type Schema struct {
+	Type        string             `json:"type"`
+	Description string             `json:"description,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	// PropertyOrdering is the field declaration order; only Gemini supports it
+	PropertyOrdering []string `json:"-"`
+}
+
+// For derives a schema from a struct value using its json tags.
+// Allowed string values are taken from the `enum:"A,B,C"` tag, descriptions from `description:"..."`.
+// Fields without omitempty are required, fields tagged `schema:"-"` are skipped.
+func For(v interface{}) *Schema {
+	return forType(reflect.TypeOf(v))
+}
+
+func forType(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return forStruct(t)
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: TypeArray, Items: forType(t.Elem())}
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeNumber}
+	default:
+		return &Schema{Type: TypeString}
+	}
+}
+
+func forStruct(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       TypeObject,
+		Properties: make(map[string]*Schema, t.NumField()),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("schema") == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := forType(field.Type)
+		if enum := field.Tag.Get("enum"); enum != "" {
+			property.Enum = strings.Split(enum, ",")
+		}
+		property.Description = field.Tag.Get("description")
+
+		schema.Properties[name] = property
+		schema.PropertyOrdering = append(schema.PropertyOrdering, name)
+
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
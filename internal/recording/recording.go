@@ -0,0 +1,120 @@
+package recording
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/drivers/tools/audio"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+// Normalizer converts a recording to the canonical format used for transcription.
+type Normalizer interface {
+	Normalize(ctx context.Context, src io.Reader, extension string) (entity.NormalizedAudio, error)
+}
+
+// File is a recording that can be read several times: for validation, normalization and upload.
+type File interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+}
+
+// Inspector validates uploaded recordings against the answer limits and normalizes them,
+// so that every use case accepting audio applies the same rules.
+type Inspector struct {
+	limits *config.Answers
+	// normalizer is nil when normalization is disabled
+	normalizer Normalizer
+}
+
+func New(limits *config.Answers, normalizer Normalizer) Inspector {
+	return Inspector{
+		limits:     limits,
+		normalizer: normalizer,
+	}
+}
+
+// Inspect validates the recording and collects its metadata and detected format. The object name is left
+// for the caller. The file is rewound to the beginning afterwards.
+func (i *Inspector) Inspect(file File, size int64) (entity.AnswerFile, audio.Format, error) {
+	if size <= 0 {
+		return entity.AnswerFile{}, audio.Format{}, errs.New(errs.ErrInvalidAudio, "empty file")
+	}
+
+	if size > i.limits.MaxSize {
+		return entity.AnswerFile{}, audio.Format{}, errs.New(errs.ErrInvalidAudio, fmt.Sprintf("file is larger than %d bytes", i.limits.MaxSize))
+	}
+
+	head := make([]byte, audio.SniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return entity.AnswerFile{}, audio.Format{}, errs.New(errs.ErrInvalidAudio, "read file: "+err.Error())
+	}
+
+	format, err := audio.Sniff(head[:n])
+	if err != nil {
+		return entity.AnswerFile{}, audio.Format{}, err
+	}
+
+	answerFile := entity.AnswerFile{
+		ContentType: format.MIMEType,
+		Size:        size,
+	}
+
+	// Для форматов без длительности в заголовке остается только ограничение размера
+	if duration, ok := audio.Duration(file, size, format); ok {
+		if duration > i.limits.MaxDuration {
+			return entity.AnswerFile{}, audio.Format{}, errs.New(errs.ErrInvalidAudio, "recording is longer than "+i.limits.MaxDuration.String())
+		}
+
+		duration = duration.Round(time.Millisecond)
+		answerFile.Duration = &duration
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return entity.AnswerFile{}, audio.Format{}, errs.New(errs.ErrUseCaseExecution, "file.Seek: "+err.Error())
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return entity.AnswerFile{}, audio.Format{}, errs.New(errs.ErrUseCaseExecution, "io.Copy: "+err.Error())
+	}
+
+	answerFile.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return entity.AnswerFile{}, audio.Format{}, errs.New(errs.ErrUseCaseExecution, "file.Seek: "+err.Error())
+	}
+
+	return answerFile, format, nil
+}
+
+// Normalize converts an inspected recording and rewinds the file. It returns nil when normalization
+// is disabled. The duration limit is applied to the speech duration when the container header has none.
+func (i *Inspector) Normalize(ctx context.Context, file File, inspected entity.AnswerFile, format audio.Format) (*entity.NormalizedAudio, error) {
+	if i.normalizer == nil {
+		return nil, nil
+	}
+
+	normalized, err := i.normalizer.Normalize(ctx, file, format.Extension)
+	if err != nil {
+		return nil, errs.Wrap("i.normalizer.Normalize", err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errs.New(errs.ErrUseCaseExecution, "file.Seek: "+err.Error())
+	}
+
+	if inspected.Duration == nil && normalized.Duration > i.limits.MaxDuration {
+		return nil, errs.New(errs.ErrInvalidAudio, "recording is longer than "+i.limits.MaxDuration.String())
+	}
+
+	return &normalized, nil
+}
@@ -0,0 +1,58 @@
+package reprompt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/jsonschema"
+
+	"go.uber.org/zap"
+)
+
+const (
+	feedbackTmpl = "\n\nYour previous reply was:\n%s\n\nIt was rejected because:\n- %s\n\nReply again with the corrected JSON only."
+)
+
+type TextAnalyzer interface {
+	AnalyzeText(ctx context.Context, prompt string, schema *jsonschema.Schema) (string, error)
+}
+
+// Ask sends the prompt to the model in JSON mode and parses the reply. While parse lists problems, the prompt
+// is sent again with the rejected reply and the problems appended, at most retries times. When the retries
+// run out, ErrInvalidModelReply is returned.
+func Ask[T any](
+	ctx context.Context,
+	logger *zap.Logger,
+	analyzer TextAnalyzer,
+	prompt string,
+	schema *jsonschema.Schema,
+	retries int,
+	parse func(reply string) (T, []string),
+) (T, error) {
+	var zero T
+
+	attemptPrompt := prompt
+
+	for attempt := 0; ; attempt++ {
+		reply, err := analyzer.AnalyzeText(ctx, attemptPrompt, schema)
+		if err != nil {
+			return zero, errs.Wrap("analyzer.AnalyzeText", err)
+		}
+
+		result, problems := parse(reply)
+		if len(problems) == 0 {
+			return result, nil
+		}
+
+		if attempt >= retries {
+			return zero, errs.New(errs.ErrInvalidModelReply, "invalid reply: "+strings.Join(problems, "; "))
+		}
+
+		logger.Warn("invalid reply, re-prompting", zap.Int("attempt", attempt+1), zap.Strings("problems", problems))
+
+		// Ошибки дописываются к исходному промпту, а не к предыдущей попытке, чтобы промпт не рос
+		attemptPrompt = prompt + fmt.Sprintf(feedbackTmpl, reply, strings.Join(problems, "\n- "))
+	}
+}
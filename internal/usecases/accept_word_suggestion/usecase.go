@@ -0,0 +1,69 @@
+package accept_word_suggestion
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+
+	"github.com/google/uuid"
+)
+
+type StorageProvider interface {
+	GetWordCollectionByID(ctx context.Context, collectionID string, userID int) (storage.WordCollection, error)
+	AcceptWordSuggestion(ctx context.Context, collectionID string, suggestion entity.AISuggestion) (storage.UserWord, error)
+}
+
+type UseCase struct {
+	storage StorageProvider
+}
+
+func New(storage StorageProvider) UseCase {
+	return UseCase{
+		storage: storage,
+	}
+}
+
+// AcceptSuggestion adds the suggested word to the collection and removes it from the suggestions.
+// The word is matched case-insensitively; stale suggestions can be accepted too.
+func (u *UseCase) AcceptSuggestion(ctx context.Context, collectionID string, userID int, word string) (entity.UserWord, error) {
+	if _, err := uuid.Parse(collectionID); err != nil {
+		return entity.UserWord{}, errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+	}
+
+	collection, err := u.storage.GetWordCollectionByID(ctx, collectionID, userID)
+	if err != nil {
+		return entity.UserWord{}, errs.Wrap("u.storage.GetWordCollectionByID", err)
+	}
+
+	var suggestions []entity.AISuggestion
+	if collection.AISuggestions != nil {
+		if err := json.Unmarshal([]byte(*collection.AISuggestions), &suggestions); err != nil {
+			return entity.UserWord{}, errs.New(errs.ErrUseCaseExecution, "json.Unmarshal: "+err.Error())
+		}
+	}
+
+	word = strings.TrimSpace(word)
+
+	var suggestion *entity.AISuggestion
+	for i := range suggestions {
+		if strings.EqualFold(suggestions[i].Word, word) {
+			suggestion = &suggestions[i]
+			break
+		}
+	}
+
+	if suggestion == nil {
+		return entity.UserWord{}, errs.New(errs.ErrNotFound, "suggestion not found: "+word)
+	}
+
+	userWord, err := u.storage.AcceptWordSuggestion(ctx, collectionID, *suggestion)
+	if err != nil {
+		return entity.UserWord{}, errs.Wrap("u.storage.AcceptWordSuggestion", err)
+	}
+
+	return userWord.Entity(), nil
+}
@@ -10,6 +10,7 @@ import (
 )
 
 type StorageProvider interface {
+	GetWordCollectionByID(ctx context.Context, collectionID string, userID int) (storage.WordCollection, error)
 	AddWordToCollection(ctx context.Context, collectionID, word, translation string, example *string) (storage.UserWord, error)
 }
 
@@ -29,7 +30,10 @@ func (u *UseCase) AddWord(ctx context.Context, collectionID, word, translation s
 		return entity.UserWord{}, errs.New(errs.ErrUseCaseExecution, "uuid.Parse: "+err.Error())
 	}
 
-	// TODO: Проверить, что коллекция принадлежит пользователю (добавить метод в storage)
+	// Проверяем, что коллекция принадлежит пользователю
+	if _, err := u.storage.GetWordCollectionByID(ctx, collectionID, userID); err != nil {
+		return entity.UserWord{}, errs.Wrap("u.storage.GetWordCollectionByID", err)
+	}
 
 	// Добавляем слово в коллекцию
 	userWord, err := u.storage.AddWordToCollection(ctx, collectionID, word, translation, example)
@@ -37,15 +41,5 @@ func (u *UseCase) AddWord(ctx context.Context, collectionID, word, translation s
 		return entity.UserWord{}, errs.New(errs.ErrUseCaseExecution, "u.storage.AddWordToCollection: "+err.Error())
 	}
 
-	return entity.UserWord{
-		ID:             userWord.ID,
-		CollectionID:   userWord.CollectionID,
-		Word:           userWord.Word,
-		Translation:    userWord.Translation,
-		Example:        userWord.Example,
-		NextReviewDate: userWord.NextReviewDate,
-		ReviewCount:    userWord.ReviewCount,
-		CreatedAt:      userWord.CreatedAt,
-		UpdatedAt:      userWord.UpdatedAt,
-	}, nil
+	return userWord.Entity(), nil
 }
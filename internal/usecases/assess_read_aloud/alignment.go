@@ -0,0 +1,173 @@
+package assess_read_aloud
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/fluency"
+)
+
+// tokenize splits the text into lowercase words without punctuation; apostrophes stay ("don't").
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.Trim(field, "'"); field != "" {
+			words = append(words, field)
+		}
+	}
+
+	return words
+}
+
+// align matches the recognized words with the reference by the minimal edit distance over words.
+// A substitution costs from 0 for equal words to 2, the price of an omission plus an insertion, for
+// unrelated ones, so a mispronounced word is aligned with its reference while an extra word doesn't
+// shift the rest of the alignment.
+func align(reference []string, spoken []entity.TranscribedWord) entity.ReadAloudResult {
+	// Междометия не относятся к тексту и не считаются вставками
+	heard := make([]entity.TranscribedWord, 0, len(spoken))
+	heardTokens := make([]string, 0, len(spoken))
+	for _, word := range spoken {
+		tokens := tokenize(word.Word)
+		if len(tokens) == 0 || fluency.IsFiller(word.Word) {
+			continue
+		}
+
+		heard = append(heard, word)
+		heardTokens = append(heardTokens, strings.Join(tokens, ""))
+	}
+
+	n, m := len(reference), len(heard)
+
+	cost := make([][]float64, n+1)
+	for i := range cost {
+		cost[i] = make([]float64, m+1)
+		cost[i][0] = float64(i)
+	}
+	for j := 0; j <= m; j++ {
+		cost[0][j] = float64(j)
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost[i][j] = min(
+				cost[i-1][j-1]+substitutionCost(reference[i-1], heardTokens[j-1]),
+				cost[i-1][j]+1,
+				cost[i][j-1]+1,
+			)
+		}
+	}
+
+	var result entity.ReadAloudResult
+
+	// Обратный проход восстанавливает выравнивание с конца
+	words := make([]entity.ReadAloudWord, 0, n+m)
+	for i, j := n, m; i > 0 || j > 0; {
+		switch {
+		case i > 0 && j > 0 && almostEqual(cost[i][j], cost[i-1][j-1]+substitutionCost(reference[i-1], heardTokens[j-1])):
+			word := entity.ReadAloudWord{
+				Reference: reference[i-1],
+				Spoken:    heardTokens[j-1],
+				Status:    entity.ReadAloudCorrect,
+				Start:     heard[j-1].Start,
+				End:       heard[j-1].End,
+			}
+
+			sim := similarity(reference[i-1], heardTokens[j-1])
+			if sim < 1 {
+				word.Status = entity.ReadAloudSubstituted
+				result.Substitutions++
+			}
+
+			word.Accuracy = round(100 * sim * heard[j-1].Confidence)
+			words = append(words, word)
+			i, j = i-1, j-1
+		case i > 0 && almostEqual(cost[i][j], cost[i-1][j]+1):
+			words = append(words, entity.ReadAloudWord{
+				Reference: reference[i-1],
+				Status:    entity.ReadAloudOmitted,
+			})
+			result.Omissions++
+			i--
+		default:
+			words = append(words, entity.ReadAloudWord{
+				Spoken: heardTokens[j-1],
+				Status: entity.ReadAloudInserted,
+				Start:  heard[j-1].Start,
+				End:    heard[j-1].End,
+			})
+			result.Insertions++
+			j--
+		}
+	}
+
+	var total float64
+	for left, right := 0, len(words)-1; left < right; left, right = left+1, right-1 {
+		words[left], words[right] = words[right], words[left]
+	}
+	for _, word := range words {
+		if word.Status != entity.ReadAloudInserted {
+			total += word.Accuracy
+		}
+	}
+
+	result.Words = words
+	if n > 0 {
+		result.Accuracy = round(total / float64(n))
+	}
+
+	return result
+}
+
+func substitutionCost(reference, spoken string) float64 {
+	return 2 * (1 - similarity(reference, spoken))
+}
+
+// similarity is 1 minus the normalized letter edit distance: 1 for equal words, 0 for unrelated ones.
+func similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			substitution := previous[j-1]
+			if ra[i-1] != rb[j-1] {
+				substitution++
+			}
+
+			current[j] = min(substitution, previous[j]+1, current[j-1]+1)
+		}
+
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(rb)])/float64(longest)
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
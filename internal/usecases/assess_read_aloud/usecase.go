@@ -0,0 +1,210 @@
+package assess_read_aloud
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"regexp"
+	"strings"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/drivers/tools/audio"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/recording"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxReferenceWords keeps the exercise to a sentence or two: longer texts are better practiced in parts.
+const maxReferenceWords = 60
+
+var sentenceEnd = regexp.MustCompile(`[.!?]+["')\]]*\s+`)
+
+type ReferenceProvider interface {
+	GetArticleByID(ctx context.Context, id int) (storage.Article, error)
+	GetUserWordByID(ctx context.Context, wordID string, userID int) (storage.UserWord, error)
+}
+
+type RecordingStorage interface {
+	UploadAnswer(ctx context.Context, filename string, file io.Reader, size int64, contentType string) error
+	DeleteAnswer(ctx context.Context, filename string) error
+	GenerateUrl(ctx context.Context, imagePath string, isAnswer bool) (string, error)
+}
+
+// RecordingInspector validates recordings and converts them to the format used for transcription.
+type RecordingInspector interface {
+	Inspect(file recording.File, size int64) (entity.AnswerFile, audio.Format, error)
+	Normalize(ctx context.Context, file recording.File, inspected entity.AnswerFile, format audio.Format) (*entity.NormalizedAudio, error)
+}
+
+type AudioTranscriber interface {
+	TranscribeAudio(ctx context.Context, audio entity.AudioSource) (entity.Transcription, error)
+}
+
+type UseCase struct {
+	logger *zap.Logger
+
+	references       ReferenceProvider
+	recordings       RecordingStorage
+	audioTranscriber AudioTranscriber
+	inspector        RecordingInspector
+}
+
+func New(
+	logger *zap.Logger,
+	references ReferenceProvider,
+	recordings RecordingStorage,
+	audioTranscriber AudioTranscriber,
+	inspector RecordingInspector,
+) UseCase {
+	return UseCase{
+		logger:           logger,
+		references:       references,
+		recordings:       recordings,
+		audioTranscriber: audioTranscriber,
+		inspector:        inspector,
+	}
+}
+
+// AssessReadAloud transcribes the recording of the reference text read aloud and aligns the recognized
+// words with the reference. The recording is only kept for the time of the transcription.
+func (u *UseCase) AssessReadAloud(
+	ctx context.Context,
+	userID int,
+	source entity.ReadAloudSource,
+	file *multipart.File,
+	header *multipart.FileHeader,
+) (entity.ReadAloudResult, error) {
+	reference, err := u.reference(ctx, userID, source)
+	if err != nil {
+		return entity.ReadAloudResult{}, err
+	}
+
+	referenceWords := tokenize(reference)
+	if len(referenceWords) == 0 {
+		return entity.ReadAloudResult{}, errs.New(errs.ErrDecodingJSON, "reference text has no words")
+	}
+
+	if len(referenceWords) > maxReferenceWords {
+		return entity.ReadAloudResult{}, errs.New(errs.ErrDecodingJSON, fmt.Sprintf("reference text is longer than %d words", maxReferenceWords))
+	}
+
+	inspected, format, err := u.inspector.Inspect(*file, header.Size)
+	if err != nil {
+		return entity.ReadAloudResult{}, errs.Wrap("u.inspector.Inspect", err)
+	}
+
+	normalized, err := u.inspector.Normalize(ctx, *file, inspected, format)
+	if err != nil {
+		return entity.ReadAloudResult{}, errs.Wrap("u.inspector.Normalize", err)
+	}
+
+	// Для распознавания достаточно нормализованной записи, оригинал не хранится
+	var (
+		upload      io.Reader = *file
+		size                  = inspected.Size
+		extension             = format.Extension
+		contentType           = inspected.ContentType
+	)
+	if normalized != nil {
+		upload = bytes.NewReader(normalized.Data)
+		size = int64(len(normalized.Data))
+		extension = normalized.Extension
+		contentType = normalized.ContentType
+	}
+
+	objectName := fmt.Sprintf("read_aloud/%d/%s.%s", userID, uuid.NewString(), extension)
+
+	if err := u.recordings.UploadAnswer(ctx, objectName, upload, size, contentType); err != nil {
+		return entity.ReadAloudResult{}, errs.Wrap("u.recordings.UploadAnswer", err)
+	}
+
+	defer func() {
+		if err := u.recordings.DeleteAnswer(context.WithoutCancel(ctx), objectName); err != nil {
+			u.logger.Error("u.recordings.DeleteAnswer", zap.String("object", objectName), zap.Error(err))
+		}
+	}()
+
+	url, err := u.recordings.GenerateUrl(ctx, objectName, true)
+	if err != nil {
+		return entity.ReadAloudResult{}, errs.Wrap("u.recordings.GenerateUrl", err)
+	}
+
+	transcription, err := u.audioTranscriber.TranscribeAudio(ctx, entity.AudioSource{
+		ObjectName: objectName,
+		URL:        url,
+	})
+	if err != nil {
+		return entity.ReadAloudResult{}, errs.Wrap("u.audioTranscriber.TranscribeAudio", err)
+	}
+
+	// Без временных меток слов (whisper) выравнивание строится по тексту с полной уверенностью
+	words := transcription.Words
+	if len(words) == 0 {
+		for _, word := range tokenize(transcription.Transcript) {
+			words = append(words, entity.TranscribedWord{Word: word, Confidence: 1})
+		}
+	}
+
+	result := align(referenceWords, words)
+	result.Reference = reference
+	result.Transcript = transcription.Transcript
+
+	return result, nil
+}
+
+// reference resolves the reference text of the exercise.
+func (u *UseCase) reference(ctx context.Context, userID int, source entity.ReadAloudSource) (string, error) {
+	switch {
+	case source.Text != "":
+		return strings.TrimSpace(source.Text), nil
+	case source.WordID != "":
+		word, err := u.references.GetUserWordByID(ctx, source.WordID, userID)
+		if err != nil {
+			return "", errs.Wrap("u.references.GetUserWordByID", err)
+		}
+
+		if word.Example == nil || strings.TrimSpace(*word.Example) == "" {
+			return "", errs.New(errs.ErrNotFound, "the word has no example sentence")
+		}
+
+		return strings.TrimSpace(*word.Example), nil
+	case source.ArticleID != 0:
+		article, err := u.references.GetArticleByID(ctx, source.ArticleID)
+		if err != nil {
+			return "", errs.Wrap("u.references.GetArticleByID", err)
+		}
+
+		sentences := splitSentences(article.Content)
+		if source.Sentence < 0 || source.Sentence >= len(sentences) {
+			return "", errs.New(errs.ErrNotFound, fmt.Sprintf("article has no sentence %d", source.Sentence))
+		}
+
+		return sentences[source.Sentence], nil
+	default:
+		return "", errs.New(errs.ErrDecodingJSON, "one of text, word_id or article_id is required")
+	}
+}
+
+func splitSentences(text string) []string {
+	var sentences []string
+
+	start := 0
+	for _, bounds := range sentenceEnd.FindAllStringIndex(text, -1) {
+		if sentence := strings.TrimSpace(text[start:bounds[1]]); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+
+		start = bounds[1]
+	}
+
+	if sentence := strings.TrimSpace(text[start:]); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+
+	return sentences
+}
@@ -1,11 +1,19 @@
 package attach_answer_to_session
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"mime/multipart"
 
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/drivers/tools/audio"
+	"speech-processing-service/internal/entity"
 	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/recording"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -13,18 +21,30 @@ type AnswerUploader interface {
 	UploadAnswer(
 		ctx context.Context,
 		filename string,
-		file multipart.File,
+		file io.Reader,
 		size int64,
+		contentType string,
 	) error
+	DeleteAnswer(ctx context.Context, filename string) error
 }
 
 type AnswerCreator interface {
-	CreateAnswer(
+	SaveAnswer(
 		ctx context.Context,
 		sessionID string,
 		questionID int,
-		filename string,
-	) error
+		file entity.AnswerFile,
+	) (int, []string, error)
+}
+
+// RecordingInspector validates recordings and converts them to the format used for transcription.
+type RecordingInspector interface {
+	Inspect(file recording.File, size int64) (entity.AnswerFile, audio.Format, error)
+	Normalize(ctx context.Context, file recording.File, inspected entity.AnswerFile, format audio.Format) (*entity.NormalizedAudio, error)
+}
+
+type SessionGetter interface {
+	GetSessionByID(ctx context.Context, sessionID string, userID int) (storage.Session, error)
 }
 
 type UseCase struct {
@@ -32,36 +52,123 @@ type UseCase struct {
 
 	answerUploader AnswerUploader
 	answerCreator  AnswerCreator
+	sessionGetter  SessionGetter
+	inspector      RecordingInspector
 }
 
 func New(
 	logger *zap.Logger,
 	answerUploader AnswerUploader,
 	creator AnswerCreator,
+	sessionGetter SessionGetter,
+	inspector RecordingInspector,
 ) UseCase {
 	return UseCase{
 		logger:         logger,
 		answerUploader: answerUploader,
 		answerCreator:  creator,
+		sessionGetter:  sessionGetter,
+		inspector:      inspector,
 	}
 }
 
+// AudioFile is a recording that can be read several times: for validation, normalization and upload.
+type AudioFile = recording.File
+
 func (u *UseCase) AttachAnswerToSession(
 	ctx context.Context,
 	sessionID string,
+	userID int,
 	questionID int,
 	file *multipart.File,
 	header *multipart.FileHeader,
 ) error {
-	err := u.answerUploader.UploadAnswer(ctx, header.Filename, *file, header.Size)
+	_, _, err := u.AttachRecording(ctx, sessionID, userID, questionID, *file, header.Size)
+
+	return err
+}
+
+// AttachRecording validates and stores the recording as the answer to the question and returns the answer ID
+// and the object name of the stored recording.
+func (u *UseCase) AttachRecording(
+	ctx context.Context,
+	sessionID string,
+	userID int,
+	questionID int,
+	file AudioFile,
+	size int64,
+) (int, string, error) {
+	session, err := u.sessionGetter.GetSessionByID(ctx, sessionID, userID)
+	if err != nil {
+		return 0, "", errs.Wrap("u.sessionGetter.GetSessionByID", err)
+	}
+
+	// Проверка до загрузки экономит запись в MinIO, окончательная - в транзакции SaveAnswer
+	if session.Status == entity.SessionStatusCompleted {
+		return 0, "", errs.New(errs.ErrSessionCompleted, "answers can't be changed after completion")
+	}
+
+	answerFile, format, err := u.inspector.Inspect(file, size)
 	if err != nil {
-		return errs.Wrap("u.answerUploader.UploadAnswer", err)
+		return 0, "", errs.Wrap("u.inspector.Inspect", err)
 	}
 
-	err = u.answerCreator.CreateAnswer(ctx, sessionID, questionID, header.Filename)
+	normalized, err := u.inspector.Normalize(ctx, file, answerFile, format)
 	if err != nil {
-		return errs.Wrap("u.answerCreator.CreateAnswer", err)
+		return 0, "", errs.Wrap("u.inspector.Normalize", err)
 	}
 
-	return nil
+	// Имя файла от клиента не используется: одинаковые имена у разных пользователей перезаписывали бы друг друга
+	baseName := fmt.Sprintf("sessions/%s/%d/%s", sessionID, questionID, uuid.NewString())
+	answerFile.ObjectName = baseName + "." + format.Extension
+
+	err = u.answerUploader.UploadAnswer(ctx, answerFile.ObjectName, file, answerFile.Size, answerFile.ContentType)
+	if err != nil {
+		return 0, "", errs.Wrap("u.answerUploader.UploadAnswer", err)
+	}
+
+	uploaded := []string{answerFile.ObjectName}
+
+	if normalized != nil {
+		answerFile.Normalized = &entity.NormalizedFile{
+			ObjectName: baseName + ".normalized." + normalized.Extension,
+			Duration:   normalized.Duration,
+			Loudness:   normalized.Loudness,
+		}
+
+		err = u.answerUploader.UploadAnswer(
+			ctx,
+			answerFile.Normalized.ObjectName,
+			bytes.NewReader(normalized.Data),
+			int64(len(normalized.Data)),
+			normalized.ContentType,
+		)
+		if err != nil {
+			u.deleteObjects(ctx, uploaded)
+
+			return 0, "", errs.Wrap("u.answerUploader.UploadAnswer", err)
+		}
+
+		uploaded = append(uploaded, answerFile.Normalized.ObjectName)
+	}
+
+	answerID, replaced, err := u.answerCreator.SaveAnswer(ctx, sessionID, questionID, answerFile)
+	if err != nil {
+		u.deleteObjects(ctx, uploaded)
+
+		return 0, "", errs.Wrap("u.answerCreator.SaveAnswer", err)
+	}
+
+	// Ответ уже сохранен, поэтому неудачное удаление старой записи только логируем
+	u.deleteObjects(ctx, replaced)
+
+	return answerID, answerFile.ObjectName, nil
+}
+
+func (u *UseCase) deleteObjects(ctx context.Context, objects []string) {
+	for _, object := range objects {
+		if err := u.answerUploader.DeleteAnswer(ctx, object); err != nil {
+			u.logger.Error("u.answerUploader.DeleteAnswer", zap.String("object", object), zap.Error(err))
+		}
+	}
 }
@@ -0,0 +1,61 @@
+package complete_session_job
+
+import (
+	"context"
+	"encoding/json"
+
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/jobs"
+)
+
+type SessionCompleter interface {
+	CompleteSession(
+		ctx context.Context,
+		sessionID string,
+		userID int,
+		reanalyze bool,
+		prompt entity.PromptVersion,
+		feedbackLanguage string,
+		progress func(percent int),
+	) (entity.AnalyzeTextResult, error)
+}
+
+// UseCase runs session completion inside a background job.
+type UseCase struct {
+	completer SessionCompleter
+}
+
+func New(completer SessionCompleter) UseCase {
+	return UseCase{
+		completer: completer,
+	}
+}
+
+func (u *UseCase) Handle(ctx context.Context, job entity.Job, progress jobs.ProgressFunc) ([]byte, error) {
+	var payload entity.CompleteSessionPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		// Повтор не исправит испорченный payload
+		return nil, errs.New(errs.ErrInvalidArgument, "job payload: "+err.Error())
+	}
+
+	result, err := u.completer.CompleteSession(
+		ctx,
+		payload.SessionID,
+		payload.UserID,
+		payload.Reanalyze,
+		payload.Prompt,
+		payload.FeedbackLanguage,
+		progress,
+	)
+	if err != nil {
+		return nil, errs.Wrap("u.completer.CompleteSession", err)
+	}
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	return resultBytes, nil
+}
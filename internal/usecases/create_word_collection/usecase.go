@@ -48,7 +48,7 @@ func (u *UseCase) CreateCollection(
 	if imageFile != nil && imageHeader != nil {
 		uploadedPath, err := u.imageUploader.UploadFile(ctx, imageFile, imageHeader, "collections")
 		if err != nil {
-			return entity.WordCollection{}, errs.New(errs.ErrUseCaseExecution, "u.imageUploader.UploadFile: "+err.Error())
+			return entity.WordCollection{}, errs.Wrap("u.imageUploader.UploadFile", err)
 		}
 		imagePath = uploadedPath
 
@@ -0,0 +1,87 @@
+package delete_user_words
+
+import (
+	"context"
+	"fmt"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/errs"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxWords limits a bulk delete to keep the transaction short
+	maxWords = 500
+)
+
+type StorageProvider interface {
+	GetWordCollectionByID(ctx context.Context, collectionID string, userID int) (storage.WordCollection, error)
+	DeleteUserWords(ctx context.Context, collectionID string, userID int, wordIDs []string) (int, error)
+}
+
+type UseCase struct {
+	storage StorageProvider
+}
+
+func New(storage StorageProvider) UseCase {
+	return UseCase{
+		storage: storage,
+	}
+}
+
+// DeleteWord deletes a word of the collection.
+func (u *UseCase) DeleteWord(ctx context.Context, collectionID, wordID string, userID int) error {
+	if _, err := uuid.Parse(collectionID); err != nil {
+		return errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+	}
+
+	if _, err := uuid.Parse(wordID); err != nil {
+		return errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+	}
+
+	deleted, err := u.storage.DeleteUserWords(ctx, collectionID, userID, []string{wordID})
+	if err != nil {
+		return errs.Wrap("u.storage.DeleteUserWords", err)
+	}
+
+	if deleted == 0 {
+		return errs.New(errs.ErrNotFound, "word not found or access denied")
+	}
+
+	return nil
+}
+
+// DeleteWords deletes the words of the collection and returns how many were deleted.
+// Words that are already deleted or belong to other collections are skipped.
+func (u *UseCase) DeleteWords(ctx context.Context, collectionID string, userID int, wordIDs []string) (int, error) {
+	if _, err := uuid.Parse(collectionID); err != nil {
+		return 0, errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+	}
+
+	if len(wordIDs) == 0 {
+		return 0, errs.New(errs.ErrDecodingJSON, "word_ids must not be empty")
+	}
+
+	if len(wordIDs) > maxWords {
+		return 0, errs.New(errs.ErrDecodingJSON, fmt.Sprintf("at most %d words can be deleted at once", maxWords))
+	}
+
+	for _, wordID := range wordIDs {
+		if _, err := uuid.Parse(wordID); err != nil {
+			return 0, errs.New(errs.ErrTypeMustBeUUID, "word id: "+wordID)
+		}
+	}
+
+	// Без проверки чужая коллекция выглядела бы как коллекция без этих слов
+	if _, err := u.storage.GetWordCollectionByID(ctx, collectionID, userID); err != nil {
+		return 0, errs.Wrap("u.storage.GetWordCollectionByID", err)
+	}
+
+	deleted, err := u.storage.DeleteUserWords(ctx, collectionID, userID, wordIDs)
+	if err != nil {
+		return 0, errs.Wrap("u.storage.DeleteUserWords", err)
+	}
+
+	return deleted, nil
+}
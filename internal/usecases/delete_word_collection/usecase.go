@@ -2,39 +2,53 @@ package delete_word_collection
 
 import (
 	"context"
+
 	"speech-processing-service/internal/errs"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 type StorageProvider interface {
-	DeleteWordCollection(ctx context.Context, collectionID uuid.UUID, userID int) error
+	DeleteWordCollection(ctx context.Context, collectionID uuid.UUID, userID int) (string, error)
+}
+
+type ImageDeleter interface {
+	DeleteFile(ctx context.Context, filename string) error
 }
 
 type UseCase struct {
-	storage StorageProvider
+	logger       *zap.Logger
+	storage      StorageProvider
+	imageDeleter ImageDeleter
 }
 
-func New(storage StorageProvider) UseCase {
+func New(logger *zap.Logger, storage StorageProvider, imageDeleter ImageDeleter) UseCase {
 	return UseCase{
-		storage: storage,
+		logger:       logger,
+		storage:      storage,
+		imageDeleter: imageDeleter,
 	}
 }
 
+// DeleteCollection deletes the collection together with its cover image.
 func (u *UseCase) DeleteCollection(ctx context.Context, collectionID string, userID int) error {
 	id, err := uuid.Parse(collectionID)
 	if err != nil {
-		return errs.New(errs.ErrUseCaseExecution, "uuid.Parse: "+err.Error())
+		return errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
 	}
 
-	if err := u.storage.DeleteWordCollection(ctx, id, userID); err != nil {
-		return errs.New(errs.ErrUseCaseExecution, "u.storage.DeleteWordCollection: "+err.Error())
+	imagePath, err := u.storage.DeleteWordCollection(ctx, id, userID)
+	if err != nil {
+		return errs.Wrap("u.storage.DeleteWordCollection", err)
 	}
 
-	// TODO: Удаление изображения из MinIO можно добавить позже
-	// if collection.ImagePath != "" {
-	//     u.imageDeleter.DeleteFile(ctx, collection.ImagePath)
-	// }
+	// Коллекция уже удалена, поэтому ошибку MinIO только логируем
+	if imagePath != "" {
+		if err := u.imageDeleter.DeleteFile(ctx, imagePath); err != nil {
+			u.logger.Error("u.imageDeleter.DeleteFile", zap.String("object", imagePath), zap.Error(err))
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,138 @@
+package enqueue_session_completion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+const (
+	dedupeKeyPrefix = entity.JobTypeCompleteSession + ":"
+)
+
+type StorageProvider interface {
+	GetSessionByID(ctx context.Context, sessionID string, userID int) (storage.Session, error)
+	CreateJob(ctx context.Context, jobType string, userID int, dedupeKey string, payload []byte, maxAttempts int) (storage.Job, error)
+	GetActiveJobByDedupeKey(ctx context.Context, dedupeKey string) (storage.Job, error)
+}
+
+type PromptResolver interface {
+	Resolve(prompt entity.PromptVersion) (entity.PromptVersion, error)
+}
+
+type UseCase struct {
+	storage     StorageProvider
+	prompts     PromptResolver
+	maxAttempts int
+}
+
+func New(storage StorageProvider, prompts PromptResolver, maxAttempts int) UseCase {
+	return UseCase{
+		storage:     storage,
+		prompts:     prompts,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// EnqueueCompletion schedules session completion in the background. While a completion job
+// for the session is queued or running, that job is returned instead of a new one; if the active job
+// uses another prompt or feedback language, ErrAlreadyExists is returned instead.
+// The prompt and the language are checked here so that a typo fails the request rather than the job.
+func (u *UseCase) EnqueueCompletion(
+	ctx context.Context,
+	sessionID string,
+	userID int,
+	reanalyze bool,
+	prompt entity.PromptVersion,
+	feedbackLanguage string,
+) (entity.Job, error) {
+	if _, err := u.storage.GetSessionByID(ctx, sessionID, userID); err != nil {
+		return entity.Job{}, errs.Wrap("u.storage.GetSessionByID", err)
+	}
+
+	if prompt != (entity.PromptVersion{}) {
+		check := prompt
+		if check.Name == "" {
+			check.Name = entity.DefaultAnalysisPrompt
+		}
+
+		if _, err := u.prompts.Resolve(check); err != nil {
+			return entity.Job{}, errs.Wrap("u.prompts.Resolve", err)
+		}
+	}
+
+	if feedbackLanguage != "" {
+		if _, ok := entity.FeedbackLanguages[feedbackLanguage]; !ok {
+			return entity.Job{}, errs.New(errs.ErrDecodingJSON, "unsupported feedback_language: "+feedbackLanguage)
+		}
+	}
+
+	payload, err := json.Marshal(entity.CompleteSessionPayload{
+		SessionID: sessionID,
+		UserID:    userID,
+		Reanalyze: reanalyze,
+		Prompt:    prompt,
+
+		FeedbackLanguage: feedbackLanguage,
+	})
+	if err != nil {
+		return entity.Job{}, errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	dedupeKey := dedupeKeyPrefix + sessionID
+
+	job, err := u.storage.CreateJob(ctx, entity.JobTypeCompleteSession, userID, dedupeKey, payload, u.maxAttempts)
+	if errors.Is(err, errs.ErrAlreadyExists) {
+		job, err = u.activeJob(ctx, dedupeKey, prompt, feedbackLanguage)
+	}
+	if err != nil {
+		return entity.Job{}, errs.Wrap("u.storage.CreateJob", err)
+	}
+
+	return entity.Job{
+		ID:          job.ID,
+		Type:        job.Type,
+		UserID:      job.UserID,
+		Status:      job.Status,
+		Progress:    job.Progress,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LastError:   job.LastError,
+		RunAt:       job.RunAt,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		FinishedAt:  job.FinishedAt,
+	}, nil
+}
+
+// activeJob returns the queued or running completion of the session if it analyzes with the requested
+// prompt and language. Otherwise the caller would silently get an analysis it didn't ask for.
+func (u *UseCase) activeJob(
+	ctx context.Context,
+	dedupeKey string,
+	prompt entity.PromptVersion,
+	feedbackLanguage string,
+) (storage.Job, error) {
+	job, err := u.storage.GetActiveJobByDedupeKey(ctx, dedupeKey)
+	if err != nil {
+		return storage.Job{}, errs.Wrap("u.storage.GetActiveJobByDedupeKey", err)
+	}
+
+	var active entity.CompleteSessionPayload
+	if err := json.Unmarshal(job.Payload, &active); err != nil {
+		return storage.Job{}, errs.New(errs.ErrDecodingJSON, "job payload: "+err.Error())
+	}
+
+	if active.Prompt != prompt || active.FeedbackLanguage != feedbackLanguage {
+		return storage.Job{}, errs.New(
+			errs.ErrAlreadyExists,
+			"session completion "+job.ID+" with another prompt or feedback language is already in progress",
+		)
+	}
+
+	return job, nil
+}
@@ -61,17 +61,7 @@ func (u *UseCase) GetCollectionDetail(ctx context.Context, collectionID string,
 	// Преобразование слов
 	userWords := make([]entity.UserWord, 0, len(words))
 	for _, word := range words {
-		userWords = append(userWords, entity.UserWord{
-			ID:             word.ID,
-			CollectionID:   word.CollectionID,
-			Word:           word.Word,
-			Translation:    word.Translation,
-			Example:        word.Example,
-			NextReviewDate: word.NextReviewDate,
-			ReviewCount:    word.ReviewCount,
-			CreatedAt:      word.CreatedAt,
-			UpdatedAt:      word.UpdatedAt,
-		})
+		userWords = append(userWords, word.Entity())
 	}
 
 	// Парсинг AI рекомендаций
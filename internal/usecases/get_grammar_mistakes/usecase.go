@@ -0,0 +1,150 @@
+package get_grammar_mistakes
+
+import (
+	"context"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+const (
+	examplesPerCategory = 3
+	articlesPerCategory = 3
+)
+
+type StorageProvider interface {
+	GetGrammarMistakeStats(ctx context.Context, userID, limit int) ([]storage.GrammarMistakeStat, error)
+	GetGrammarMistakeExamples(
+		ctx context.Context,
+		userID int,
+		categories []string,
+		perCategory int,
+	) ([]storage.GrammarMistake, error)
+	GetArticlesByGrammarCategories(
+		ctx context.Context,
+		categories []string,
+		level string,
+		perCategory int,
+	) ([]storage.CategoryArticle, error)
+	GetUserSessionMetrics(ctx context.Context, userID, limit int) ([]storage.SessionMetrics, error)
+}
+
+type URLGetter interface {
+	GenerateUrl(ctx context.Context, imagePath string, isAnswer bool) (string, error)
+}
+
+type UseCase struct {
+	storage   StorageProvider
+	urlGetter URLGetter
+}
+
+func New(storage StorageProvider, urlGetter URLGetter) UseCase {
+	return UseCase{
+		storage:   storage,
+		urlGetter: urlGetter,
+	}
+}
+
+// GetMistakes ranks the rule categories the user breaks most often, with the latest examples and the articles
+// that cover the rule, the ones closest to the level of the last session first.
+func (u *UseCase) GetMistakes(ctx context.Context, userID, limit int) ([]entity.GrammarMistakeCategory, error) {
+	stats, err := u.storage.GetGrammarMistakeStats(ctx, userID, limit)
+	if err != nil {
+		return nil, errs.Wrap("u.storage.GetGrammarMistakeStats", err)
+	}
+
+	if len(stats) == 0 {
+		return []entity.GrammarMistakeCategory{}, nil
+	}
+
+	categories := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		categories = append(categories, stat.Category)
+	}
+
+	examples, err := u.storage.GetGrammarMistakeExamples(ctx, userID, categories, examplesPerCategory)
+	if err != nil {
+		return nil, errs.Wrap("u.storage.GetGrammarMistakeExamples", err)
+	}
+
+	articles, err := u.recommendArticles(ctx, userID, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	examplesByCategory := make(map[string][]entity.GrammarMistake, len(stats))
+	for _, example := range examples {
+		examplesByCategory[example.Category] = append(examplesByCategory[example.Category], entity.GrammarMistake{
+			SessionID:         example.SessionID,
+			AnswerID:          example.AnswerID,
+			Sentence:          example.Sentence,
+			CorrectedSentence: example.CorrectedSentence,
+			Explanation:       example.Explanation,
+			CreatedAt:         example.CreatedAt,
+		})
+	}
+
+	result := make([]entity.GrammarMistakeCategory, 0, len(stats))
+	for _, stat := range stats {
+		category := entity.GrammarMistakeCategory{
+			Category:      stat.Category,
+			Count:         stat.Mistakes,
+			SessionsCount: stat.Sessions,
+			LastSeenAt:    stat.LastSeenAt,
+			Examples:      examplesByCategory[stat.Category],
+			Articles:      articles[stat.Category],
+		}
+
+		if category.Examples == nil {
+			category.Examples = []entity.GrammarMistake{}
+		}
+		if category.Articles == nil {
+			category.Articles = []entity.ArticlePreview{}
+		}
+
+		result = append(result, category)
+	}
+
+	return result, nil
+}
+
+func (u *UseCase) recommendArticles(
+	ctx context.Context,
+	userID int,
+	categories []string,
+) (map[string][]entity.ArticlePreview, error) {
+	// Без завершенных сессий уровень неизвестен, и статьи идут от новых к старым
+	var level string
+
+	lastSession, err := u.storage.GetUserSessionMetrics(ctx, userID, 1)
+	if err != nil {
+		return nil, errs.Wrap("u.storage.GetUserSessionMetrics", err)
+	}
+	if len(lastSession) > 0 {
+		level = lastSession[0].OverallLevel
+	}
+
+	articles, err := u.storage.GetArticlesByGrammarCategories(ctx, categories, level, articlesPerCategory)
+	if err != nil {
+		return nil, errs.Wrap("u.storage.GetArticlesByGrammarCategories", err)
+	}
+
+	result := make(map[string][]entity.ArticlePreview, len(categories))
+	for _, article := range articles {
+		imageURL, err := u.urlGetter.GenerateUrl(ctx, article.ImageURL, false)
+		if err != nil {
+			return nil, errs.Wrap("u.urlGetter.GenerateUrl", err)
+		}
+
+		result[article.Category] = append(result[article.Category], entity.ArticlePreview{
+			ID:            article.ID,
+			ImageURL:      imageURL,
+			Level:         article.Level,
+			MinutesToRead: article.MinutesToRead,
+			Title:         article.Title,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package get_job
+
+import (
+	"context"
+	"encoding/json"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+type StorageProvider interface {
+	GetJobByID(ctx context.Context, jobID string, userID int) (storage.Job, error)
+}
+
+type UseCase struct {
+	storage StorageProvider
+}
+
+func New(storage StorageProvider) UseCase {
+	return UseCase{
+		storage: storage,
+	}
+}
+
+func (u *UseCase) GetJob(ctx context.Context, jobID string, userID int) (entity.Job, error) {
+	job, err := u.storage.GetJobByID(ctx, jobID, userID)
+	if err != nil {
+		return entity.Job{}, errs.Wrap("u.storage.GetJobByID", err)
+	}
+
+	result := entity.Job{
+		ID:          job.ID,
+		Type:        job.Type,
+		UserID:      job.UserID,
+		Status:      job.Status,
+		Progress:    job.Progress,
+		Result:      job.Result,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LastError:   job.LastError,
+		RunAt:       job.RunAt,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+
+	if job.Type == entity.JobTypeCompleteSession && len(job.Result) > 0 {
+		var analysis entity.AnalyzeTextResult
+		if err := json.Unmarshal(job.Result, &analysis); err != nil {
+			return entity.Job{}, errs.New(errs.ErrDecodingJSON, "job result: "+err.Error())
+		}
+
+		result.SessionAnalysis = &analysis
+	}
+
+	return result, nil
+}
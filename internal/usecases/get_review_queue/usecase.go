@@ -0,0 +1,61 @@
+package get_review_queue
+
+import (
+	"context"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+
+	"github.com/google/uuid"
+)
+
+type StorageProvider interface {
+	GetWordCollectionByID(ctx context.Context, collectionID string, userID int) (storage.WordCollection, error)
+	GetDueUserWords(ctx context.Context, collectionID string, limit int) ([]storage.UserWord, error)
+	CountDueUserWords(ctx context.Context, collectionID string) (int, error)
+}
+
+type UseCase struct {
+	storage StorageProvider
+}
+
+func New(storage StorageProvider) UseCase {
+	return UseCase{
+		storage: storage,
+	}
+}
+
+// GetReviewQueue returns up to limit words of the collection due for review and the number of all due words.
+func (u *UseCase) GetReviewQueue(ctx context.Context, collectionID string, userID, limit int) (entity.ReviewQueue, error) {
+	if _, err := uuid.Parse(collectionID); err != nil {
+		return entity.ReviewQueue{}, errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+	}
+
+	// Проверяем, что коллекция принадлежит пользователю
+	if _, err := u.storage.GetWordCollectionByID(ctx, collectionID, userID); err != nil {
+		return entity.ReviewQueue{}, errs.Wrap("u.storage.GetWordCollectionByID", err)
+	}
+
+	words, err := u.storage.GetDueUserWords(ctx, collectionID, limit)
+	if err != nil {
+		return entity.ReviewQueue{}, errs.Wrap("u.storage.GetDueUserWords", err)
+	}
+
+	dueCount, err := u.storage.CountDueUserWords(ctx, collectionID)
+	if err != nil {
+		return entity.ReviewQueue{}, errs.Wrap("u.storage.CountDueUserWords", err)
+	}
+
+	queue := entity.ReviewQueue{
+		CollectionID: collectionID,
+		DueCount:     dueCount,
+		Words:        make([]entity.UserWord, 0, len(words)),
+	}
+
+	for _, word := range words {
+		queue.Words = append(queue.Words, word.Entity())
+	}
+
+	return queue, nil
+}
@@ -0,0 +1,85 @@
+package get_session_detail
+
+import (
+	"context"
+	"encoding/json"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+
+	"go.uber.org/zap"
+)
+
+type StorageProvider interface {
+	GetSessionByID(ctx context.Context, sessionID string, userID int) (storage.Session, error)
+	GetSessionAnswers(ctx context.Context, sessionID string) ([]storage.SessionAnswer, error)
+}
+
+type URLGetter interface {
+	GenerateUrl(ctx context.Context, objectPath string, isAnswer bool) (string, error)
+}
+
+type UseCase struct {
+	logger *zap.Logger
+
+	storage   StorageProvider
+	urlGetter URLGetter
+}
+
+func New(logger *zap.Logger, storage StorageProvider, urlGetter URLGetter) UseCase {
+	return UseCase{
+		logger:    logger,
+		storage:   storage,
+		urlGetter: urlGetter,
+	}
+}
+
+func (u *UseCase) GetSessionDetail(ctx context.Context, sessionID string, userID int) (entity.SessionDetail, error) {
+	session, err := u.storage.GetSessionByID(ctx, sessionID, userID)
+	if err != nil {
+		return entity.SessionDetail{}, errs.Wrap("u.storage.GetSessionByID", err)
+	}
+
+	answersDB, err := u.storage.GetSessionAnswers(ctx, sessionID)
+	if err != nil {
+		return entity.SessionDetail{}, errs.Wrap("u.storage.GetSessionAnswers", err)
+	}
+
+	answers := make([]entity.SessionAnswer, 0, len(answersDB))
+	for _, answerDB := range answersDB {
+		audioURL, err := u.urlGetter.GenerateUrl(ctx, answerDB.Filename, true)
+		if err != nil {
+			u.logger.Error("u.urlGetter.GenerateUrl", zap.Error(err))
+		}
+
+		answers = append(answers, entity.SessionAnswer{
+			ID:           answerDB.ID,
+			QuestionID:   answerDB.QuestionID,
+			QuestionText: answerDB.QuestionText,
+			AudioURL:     audioURL,
+			Transcript:   answerDB.Transcript,
+		})
+	}
+
+	var analysis *entity.AnalyzeTextResult
+	if session.Analysis != nil && *session.Analysis != "" {
+		var result entity.AnalyzeTextResult
+		if err := json.Unmarshal([]byte(*session.Analysis), &result); err != nil {
+			u.logger.Error("json.Unmarshal", zap.Error(err))
+		} else {
+			analysis = &result
+		}
+	}
+
+	return entity.SessionDetail{
+		ID:          session.ID,
+		TopicID:     session.TopicID,
+		TopicTitle:  session.TopicTitle,
+		Status:      session.Status,
+		Answers:     answers,
+		Analysis:    analysis,
+		CreatedAt:   session.CreatedAt,
+		CompletedAt: session.CompletedAt,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package get_user_progress
+
+import (
+	"math"
+
+	"speech-processing-service/internal/entity"
+)
+
+const (
+	// smoothingWindow is how many recent sessions the current level is estimated over
+	smoothingWindow = 5
+	// trendWindow is how many recent sessions are compared with the same number before them
+	trendWindow = 3
+	// stableChange is the relative change below which a skill is considered stable
+	stableChange = 0.05
+)
+
+// currentScore is the weighted mean of the recent level scores, the newest session weighs the most.
+// One unusually good or bad session moves the estimate, but doesn't define it.
+func currentScore(series []entity.ProgressPoint) (float64, bool) {
+	var sum, weights float64
+
+	recent := series[max(len(series)-smoothingWindow, 0):]
+	for i, point := range recent {
+		if point.LevelScore == 0 {
+			continue
+		}
+
+		weight := float64(i + 1)
+		sum += weight * float64(point.LevelScore)
+		weights += weight
+	}
+
+	if weights == 0 {
+		return 0, false
+	}
+
+	return round(sum / weights), true
+}
+
+type skill struct {
+	name string
+	// lowerIsBetter is set for mistakes and fillers
+	lowerIsBetter bool
+	value         func(entity.ProgressPoint) *float64
+}
+
+var skills = []skill{
+	{
+		name: entity.SkillLevel,
+		value: func(p entity.ProgressPoint) *float64 {
+			if p.LevelScore == 0 {
+				return nil
+			}
+
+			score := float64(p.LevelScore)
+
+			return &score
+		},
+	},
+	{
+		name:          entity.SkillGrammar,
+		lowerIsBetter: true,
+		value: func(p entity.ProgressPoint) *float64 {
+			if p.AnswersCount == 0 {
+				return nil
+			}
+
+			return &p.GrammarIssuesPerAnswer
+		},
+	},
+	{
+		name:  entity.SkillVocabulary,
+		value: func(p entity.ProgressPoint) *float64 { return p.VocabularyScore },
+	},
+	{
+		name:  entity.SkillFluency,
+		value: func(p entity.ProgressPoint) *float64 { return p.WordsPerMinute },
+	},
+	{
+		name:          entity.SkillFillers,
+		lowerIsBetter: true,
+		value:         func(p entity.ProgressPoint) *float64 { return p.FillerRate },
+	},
+}
+
+func trends(series []entity.ProgressPoint) []entity.SkillTrend {
+	result := make([]entity.SkillTrend, 0, len(skills))
+	for _, s := range skills {
+		var values []float64
+		for _, point := range series {
+			if value := s.value(point); value != nil {
+				values = append(values, *value)
+			}
+		}
+
+		result = append(result, trend(s, values))
+	}
+
+	return result
+}
+
+// trend compares the mean of the last values with the mean of the same number of values before them.
+// With less than 2*trendWindow values both halves shrink, so two sessions already give a trend.
+func trend(s skill, values []float64) entity.SkillTrend {
+	t := entity.SkillTrend{
+		Skill:     s.name,
+		Direction: entity.TrendInsufficientData,
+	}
+
+	if len(values) > 0 {
+		recent := round(mean(values[len(values)-min(trendWindow, len(values)):]))
+		t.Recent = &recent
+	}
+
+	window := min(trendWindow, len(values)/2)
+	if window == 0 {
+		return t
+	}
+
+	recent := round(mean(values[len(values)-window:]))
+	previous := round(mean(values[len(values)-2*window : len(values)-window]))
+	change := round(recent - previous)
+
+	t.Recent = &recent
+	t.Previous = &previous
+	t.Change = &change
+
+	switch {
+	case math.Abs(change) <= stableChange*math.Abs(previous):
+		t.Direction = entity.TrendStable
+	case (change > 0) != s.lowerIsBetter:
+		t.Direction = entity.TrendImproving
+	default:
+		t.Direction = entity.TrendDeclining
+	}
+
+	return t
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
+
+// round keeps three decimals: filler rates are small fractions.
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
@@ -0,0 +1,99 @@
+package get_user_progress
+
+import (
+	"context"
+	"encoding/json"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+
+	"go.uber.org/zap"
+)
+
+type StorageProvider interface {
+	GetUserSessionMetrics(ctx context.Context, userID, limit int) ([]storage.SessionMetrics, error)
+}
+
+type UseCase struct {
+	logger  *zap.Logger
+	storage StorageProvider
+}
+
+func New(logger *zap.Logger, storage StorageProvider) UseCase {
+	return UseCase{
+		logger:  logger,
+		storage: storage,
+	}
+}
+
+// GetProgress returns the metrics of the last limit completed sessions with the estimated current level
+// and the per-skill trends.
+func (u *UseCase) GetProgress(ctx context.Context, userID, limit int) (entity.UserProgress, error) {
+	metrics, err := u.storage.GetUserSessionMetrics(ctx, userID, limit)
+	if err != nil {
+		return entity.UserProgress{}, errs.Wrap("u.storage.GetUserSessionMetrics", err)
+	}
+
+	series := make([]entity.ProgressPoint, 0, len(metrics))
+	for _, m := range metrics {
+		var vocabulary map[string]int
+		if err := json.Unmarshal([]byte(m.Vocabulary), &vocabulary); err != nil {
+			u.logger.Warn("stored vocabulary is corrupted", zap.String("session_id", m.SessionID), zap.Error(err))
+		}
+
+		series = append(series, newPoint(m, vocabulary))
+	}
+
+	progress := entity.UserProgress{
+		Series: series,
+		Trends: trends(series),
+	}
+
+	if score, ok := currentScore(series); ok {
+		level := entity.CEFRLevels[int(score+0.5)-1]
+		progress.CurrentLevel = &level
+		progress.CurrentScore = &score
+	}
+
+	return progress, nil
+}
+
+func newPoint(m storage.SessionMetrics, vocabulary map[string]int) entity.ProgressPoint {
+	if vocabulary == nil {
+		vocabulary = map[string]int{}
+	}
+
+	point := entity.ProgressPoint{
+		SessionID:   m.SessionID,
+		CompletedAt: m.CompletedAt,
+		SessionMetrics: entity.SessionMetrics{
+			OverallLevel:   m.OverallLevel,
+			AnswersCount:   m.AnswersCount,
+			GrammarIssues:  m.GrammarIssues,
+			Vocabulary:     vocabulary,
+			WordsPerMinute: m.WordsPerMinute,
+			FillerRate:     m.FillerRate,
+		},
+		LevelScore: entity.CEFRScore(m.OverallLevel),
+	}
+
+	if m.AnswersCount > 0 {
+		point.GrammarIssuesPerAnswer = round(float64(m.GrammarIssues) / float64(m.AnswersCount))
+	}
+
+	var words, score int
+	for level, count := range vocabulary {
+		if levelScore := entity.CEFRScore(level); levelScore > 0 {
+			words += count
+			score += levelScore * count
+		}
+	}
+
+	if words > 0 {
+		vocabularyScore := round(float64(score) / float64(words))
+		point.VocabularyScore = &vocabularyScore
+	}
+
+	return point
+}
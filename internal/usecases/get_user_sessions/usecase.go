@@ -0,0 +1,46 @@
+package get_user_sessions
+
+import (
+	"context"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+type StorageProvider interface {
+	GetUserSessions(ctx context.Context, userID, limit, offset int) ([]storage.SessionSummary, error)
+}
+
+type UseCase struct {
+	storage StorageProvider
+}
+
+func New(storage StorageProvider) UseCase {
+	return UseCase{
+		storage: storage,
+	}
+}
+
+func (u *UseCase) GetSessions(ctx context.Context, userID, limit, offset int) ([]entity.SessionSummary, error) {
+	sessions, err := u.storage.GetUserSessions(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, errs.Wrap("u.storage.GetUserSessions", err)
+	}
+
+	result := make([]entity.SessionSummary, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, entity.SessionSummary{
+			ID:           session.ID,
+			TopicID:      session.TopicID,
+			TopicTitle:   session.TopicTitle,
+			Status:       session.Status,
+			OverallLevel: session.OverallLevel,
+			AnswersCount: session.AnswersCount,
+			CreatedAt:    session.CreatedAt,
+			CompletedAt:  session.CompletedAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package get_user_settings
+
+import (
+	"context"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+type StorageProvider interface {
+	GetUserSettings(ctx context.Context, userID int) (storage.UserSettings, error)
+}
+
+type UseCase struct {
+	storage StorageProvider
+}
+
+func New(storage StorageProvider) UseCase {
+	return UseCase{
+		storage: storage,
+	}
+}
+
+func (u *UseCase) GetSettings(ctx context.Context, userID int) (entity.UserSettings, error) {
+	settings, err := u.storage.GetUserSettings(ctx, userID)
+	if err != nil {
+		return entity.UserSettings{}, errs.Wrap("u.storage.GetUserSettings", err)
+	}
+
+	return entity.UserSettings{
+		FeedbackLanguage: settings.FeedbackLanguage,
+		TimeZone:         settings.TimeZone,
+	}, nil
+}
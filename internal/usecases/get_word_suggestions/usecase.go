@@ -0,0 +1,231 @@
+package get_word_suggestions
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/jsonschema"
+	"speech-processing-service/internal/reprompt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var suggestionsSchema = jsonschema.For(entity.WordSuggestionsResult{})
+
+type StorageProvider interface {
+	GetWordCollectionByID(ctx context.Context, collectionID string, userID int) (storage.WordCollection, error)
+	GetUserWordsByCollectionID(ctx context.Context, collectionID string) ([]storage.UserWord, error)
+	GetWordSuggestions(ctx context.Context, collectionID string, userID int, ttl time.Duration) (storage.WordSuggestions, error)
+	SaveWordSuggestions(ctx context.Context, collectionID string, suggestions []byte) (string, error)
+	GetUserSettings(ctx context.Context, userID int) (storage.UserSettings, error)
+}
+
+type TextAnalyzer interface {
+	AnalyzeText(ctx context.Context, prompt string, schema *jsonschema.Schema) (string, error)
+}
+
+type PromptRenderer interface {
+	Resolve(prompt entity.PromptVersion) (entity.PromptVersion, error)
+	Render(prompt entity.PromptVersion, data any) (string, error)
+}
+
+type UseCase struct {
+	logger *zap.Logger
+
+	storage      StorageProvider
+	textAnalyzer TextAnalyzer
+	prompts      PromptRenderer
+
+	cfg *config.Suggestions
+	// retries is how many times an invalid reply is sent back to the model
+	retries int
+}
+
+func New(
+	logger *zap.Logger,
+	storage StorageProvider,
+	textAnalyzer TextAnalyzer,
+	prompts PromptRenderer,
+	cfg *config.Suggestions,
+	retries int,
+) UseCase {
+	return UseCase{
+		logger:       logger,
+		storage:      storage,
+		textAnalyzer: textAnalyzer,
+		prompts:      prompts,
+		cfg:          cfg,
+		retries:      retries,
+	}
+}
+
+// GetSuggestions returns the cached suggestions of the collection. New ones are generated when the cache
+// is empty, older than the TTL or refresh is set.
+func (u *UseCase) GetSuggestions(ctx context.Context, collectionID string, userID int, refresh bool) (entity.WordSuggestions, error) {
+	if _, err := uuid.Parse(collectionID); err != nil {
+		return entity.WordSuggestions{}, errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+	}
+
+	cached, err := u.storage.GetWordSuggestions(ctx, collectionID, userID, u.cfg.TTL)
+	if err != nil {
+		return entity.WordSuggestions{}, errs.Wrap("u.storage.GetWordSuggestions", err)
+	}
+
+	if !refresh && cached.Fresh && cached.Suggestions != nil && cached.GeneratedAt != nil {
+		var suggestions []entity.AISuggestion
+		// Испорченный кэш не ошибка: рекомендации просто генерируются заново
+		if err := json.Unmarshal([]byte(*cached.Suggestions), &suggestions); err == nil {
+			return entity.WordSuggestions{
+				CollectionID: collectionID,
+				Suggestions:  suggestions,
+				GeneratedAt:  *cached.GeneratedAt,
+			}, nil
+		}
+	}
+
+	collection, err := u.storage.GetWordCollectionByID(ctx, collectionID, userID)
+	if err != nil {
+		return entity.WordSuggestions{}, errs.Wrap("u.storage.GetWordCollectionByID", err)
+	}
+
+	words, err := u.storage.GetUserWordsByCollectionID(ctx, collectionID)
+	if err != nil {
+		return entity.WordSuggestions{}, errs.Wrap("u.storage.GetUserWordsByCollectionID", err)
+	}
+
+	settings, err := u.storage.GetUserSettings(ctx, userID)
+	if err != nil {
+		return entity.WordSuggestions{}, errs.Wrap("u.storage.GetUserSettings", err)
+	}
+
+	suggestions, err := u.generate(ctx, collection.Name, words, settings.FeedbackLanguage)
+	if err != nil {
+		return entity.WordSuggestions{}, err
+	}
+
+	data, err := json.Marshal(suggestions)
+	if err != nil {
+		return entity.WordSuggestions{}, errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	generatedAt, err := u.storage.SaveWordSuggestions(ctx, collectionID, data)
+	if err != nil {
+		return entity.WordSuggestions{}, errs.Wrap("u.storage.SaveWordSuggestions", err)
+	}
+
+	return entity.WordSuggestions{
+		CollectionID: collectionID,
+		Suggestions:  suggestions,
+		GeneratedAt:  generatedAt,
+	}, nil
+}
+
+// generate asks the model for new words and re-prompts it while the reply is invalid or has no new words.
+// Words of an empty collection are translated into the feedback language of the learner.
+func (u *UseCase) generate(
+	ctx context.Context,
+	collectionName string,
+	words []storage.UserWord,
+	feedbackLanguage string,
+) ([]entity.AISuggestion, error) {
+	prompt, err := u.prompts.Resolve(entity.PromptVersion{Name: entity.WordSuggestionsPrompt})
+	if err != nil {
+		return nil, errs.Wrap("u.prompts.Resolve", err)
+	}
+
+	data := entity.SuggestionPromptData{
+		CollectionName: collectionName,
+		Words:          make([]entity.PromptWord, 0, len(words)),
+		Count:          u.cfg.Count,
+
+		TranslationLanguage: languageName(feedbackLanguage),
+	}
+
+	existing := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		data.Words = append(data.Words, entity.PromptWord{
+			Word:        word.Word,
+			Translation: word.Translation,
+		})
+		existing[normalize(word.Word)] = struct{}{}
+	}
+
+	rendered, err := u.prompts.Render(prompt, data)
+	if err != nil {
+		return nil, errs.Wrap("u.prompts.Render", err)
+	}
+
+	suggestions, err := reprompt.Ask(ctx, u.logger, u.textAnalyzer, rendered, suggestionsSchema, u.retries,
+		func(reply string) ([]entity.AISuggestion, []string) {
+			return parseSuggestions(reply, existing, u.cfg.Count)
+		},
+	)
+	if err != nil {
+		return nil, errs.Wrap("reprompt.Ask", err)
+	}
+
+	return suggestions, nil
+}
+
+// languageName returns the prompt name of the language; settings saved before the languages were
+// checked fall back to the default one.
+func languageName(code string) string {
+	if name, ok := entity.FeedbackLanguages[code]; ok {
+		return name
+	}
+
+	return entity.FeedbackLanguages[entity.DefaultFeedbackLanguage]
+}
+
+// parseSuggestions keeps up to count complete suggestions of words that are not in the collection yet.
+// The problems are empty when at least one suggestion is left.
+func parseSuggestions(reply string, existing map[string]struct{}, count int) ([]entity.AISuggestion, []string) {
+	var result entity.WordSuggestionsResult
+	if err := json.Unmarshal([]byte(reply), &result); err != nil {
+		return nil, []string{"the reply is not valid JSON: " + err.Error()}
+	}
+
+	seen := make(map[string]struct{}, len(result.Suggestions))
+	suggestions := make([]entity.AISuggestion, 0, count)
+
+	for _, suggestion := range result.Suggestions {
+		suggestion.Word = strings.TrimSpace(suggestion.Word)
+		suggestion.Translation = strings.TrimSpace(suggestion.Translation)
+		suggestion.Reason = strings.TrimSpace(suggestion.Reason)
+
+		if suggestion.Word == "" || suggestion.Translation == "" {
+			continue
+		}
+
+		key := normalize(suggestion.Word)
+		if _, ok := existing[key]; ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		suggestions = append(suggestions, suggestion)
+		if len(suggestions) == count {
+			break
+		}
+	}
+
+	if len(suggestions) == 0 {
+		return nil, []string{"no new words with a translation: do not repeat the words of the collection"}
+	}
+
+	return suggestions, nil
+}
+
+func normalize(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
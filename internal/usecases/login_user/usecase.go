@@ -0,0 +1,69 @@
+package login_user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+type StorageProvider interface {
+	GetUserByEmail(ctx context.Context, email string) (storage.User, error)
+}
+
+type PasswordComparer interface {
+	ComparePassword(hash, password string) error
+}
+
+type TokenIssuer interface {
+	IssueToken(userID int) (string, time.Time, error)
+}
+
+type UseCase struct {
+	storage     StorageProvider
+	comparer    PasswordComparer
+	tokenIssuer TokenIssuer
+}
+
+func New(storage StorageProvider, comparer PasswordComparer, tokenIssuer TokenIssuer) UseCase {
+	return UseCase{
+		storage:     storage,
+		comparer:    comparer,
+		tokenIssuer: tokenIssuer,
+	}
+}
+
+func (u *UseCase) Login(ctx context.Context, email, password string) (entity.AuthToken, error) {
+	user, err := u.storage.GetUserByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		// Не раскрываем, существует ли пользователь с таким email
+		if errors.Is(err, errs.ErrNotFound) {
+			return entity.AuthToken{}, errs.New(errs.ErrUnauthorized, "invalid email or password")
+		}
+
+		return entity.AuthToken{}, errs.Wrap("u.storage.GetUserByEmail", err)
+	}
+
+	if err := u.comparer.ComparePassword(user.PasswordHash, password); err != nil {
+		return entity.AuthToken{}, errs.Wrap("u.comparer.ComparePassword", err)
+	}
+
+	token, expiresAt, err := u.tokenIssuer.IssueToken(user.ID)
+	if err != nil {
+		return entity.AuthToken{}, errs.Wrap("u.tokenIssuer.IssueToken", err)
+	}
+
+	return entity.AuthToken{
+		Token:     token,
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+		User: entity.User{
+			ID:        user.ID,
+			Email:     user.Email,
+			CreatedAt: user.CreatedAt,
+		},
+	}, nil
+}
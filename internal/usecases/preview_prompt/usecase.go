@@ -0,0 +1,72 @@
+package preview_prompt
+
+import (
+	"context"
+
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+type PromptRenderer interface {
+	Resolve(prompt entity.PromptVersion) (entity.PromptVersion, error)
+	Render(prompt entity.PromptVersion, data any) (string, error)
+	RenderDraft(text string, data any) (string, error)
+}
+
+type UseCase struct {
+	prompts PromptRenderer
+}
+
+func New(prompts PromptRenderer) UseCase {
+	return UseCase{
+		prompts: prompts,
+	}
+}
+
+// PreviewPrompt renders the prompt with the given answers exactly as it would be sent to the model.
+// A draft template is rendered instead of the stored one, so a new version can be checked before it is added.
+// Only the session analysis prompt can be previewed: the sample data are the answers of a session.
+func (u *UseCase) PreviewPrompt(ctx context.Context, preview entity.PromptPreview) (entity.PromptVersion, string, error) {
+	language := preview.FeedbackLanguage
+	if language == "" {
+		language = entity.DefaultFeedbackLanguage
+	}
+
+	name, ok := entity.FeedbackLanguages[language]
+	if !ok {
+		return entity.PromptVersion{}, "", errs.New(errs.ErrDecodingJSON, "unsupported feedback_language: "+language)
+	}
+
+	preview.Data.FeedbackLanguage = name
+
+	prompt := preview.Prompt
+	if prompt.Name == "" {
+		prompt.Name = entity.DefaultAnalysisPrompt
+	}
+
+	// Данные предпросмотра — ответы сессии, другие промпты ждут другие поля и упали бы при рендеринге
+	if prompt.Name != entity.DefaultAnalysisPrompt {
+		return entity.PromptVersion{}, "", errs.New(errs.ErrInvalidArgument, "only "+entity.DefaultAnalysisPrompt+" prompts can be previewed")
+	}
+
+	if preview.Template != "" {
+		rendered, err := u.prompts.RenderDraft(preview.Template, preview.Data)
+		if err != nil {
+			return entity.PromptVersion{}, "", errs.Wrap("u.prompts.RenderDraft", err)
+		}
+
+		return preview.Prompt, rendered, nil
+	}
+
+	prompt, err := u.prompts.Resolve(prompt)
+	if err != nil {
+		return entity.PromptVersion{}, "", errs.Wrap("u.prompts.Resolve", err)
+	}
+
+	rendered, err := u.prompts.Render(prompt, preview.Data)
+	if err != nil {
+		return entity.PromptVersion{}, "", errs.Wrap("u.prompts.Render", err)
+	}
+
+	return prompt, rendered, nil
+}
@@ -0,0 +1,84 @@
+package register_user
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+	"time"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+const (
+	minPasswordLength = 8
+	// maxPasswordLength is the bcrypt limit in bytes, longer passwords are rejected by the hasher
+	maxPasswordLength = 72
+)
+
+type StorageProvider interface {
+	CreateUser(ctx context.Context, email, passwordHash string) (storage.User, error)
+}
+
+type PasswordHasher interface {
+	HashPassword(password string) (string, error)
+}
+
+type TokenIssuer interface {
+	IssueToken(userID int) (string, time.Time, error)
+}
+
+type UseCase struct {
+	storage     StorageProvider
+	hasher      PasswordHasher
+	tokenIssuer TokenIssuer
+}
+
+func New(storage StorageProvider, hasher PasswordHasher, tokenIssuer TokenIssuer) UseCase {
+	return UseCase{
+		storage:     storage,
+		hasher:      hasher,
+		tokenIssuer: tokenIssuer,
+	}
+}
+
+func (u *UseCase) Register(ctx context.Context, email, password string) (entity.AuthToken, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if _, err := mail.ParseAddress(email); err != nil {
+		return entity.AuthToken{}, errs.New(errs.ErrDecodingJSON, "invalid email: "+email)
+	}
+
+	if len(password) < minPasswordLength {
+		return entity.AuthToken{}, errs.New(errs.ErrDecodingJSON, "password is too short")
+	}
+
+	if len(password) > maxPasswordLength {
+		return entity.AuthToken{}, errs.New(errs.ErrDecodingJSON, "password is too long")
+	}
+
+	hash, err := u.hasher.HashPassword(password)
+	if err != nil {
+		return entity.AuthToken{}, errs.Wrap("u.hasher.HashPassword", err)
+	}
+
+	user, err := u.storage.CreateUser(ctx, email, hash)
+	if err != nil {
+		return entity.AuthToken{}, errs.Wrap("u.storage.CreateUser", err)
+	}
+
+	token, expiresAt, err := u.tokenIssuer.IssueToken(user.ID)
+	if err != nil {
+		return entity.AuthToken{}, errs.Wrap("u.tokenIssuer.IssueToken", err)
+	}
+
+	return entity.AuthToken{
+		Token:     token,
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+		User: entity.User{
+			ID:        user.ID,
+			Email:     user.Email,
+			CreatedAt: user.CreatedAt,
+		},
+	}, nil
+}
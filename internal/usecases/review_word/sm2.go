@@ -0,0 +1,58 @@
+package review_word
+
+import (
+	"math"
+
+	"speech-processing-service/internal/entity"
+)
+
+const (
+	// minGrade and maxGrade bound the SM-2 recall grade: 0 is a blackout, 5 is a perfect recall
+	minGrade = 0
+	maxGrade = 5
+	// passingGrade is the lowest grade at which the word counts as remembered
+	passingGrade = 3
+
+	defaultEaseFactor = 2.5
+	minEaseFactor     = 1.3
+
+	firstIntervalDays  = 1
+	secondIntervalDays = 6
+)
+
+// nextSchedule applies the SM-2 algorithm to the schedule of a word reviewed with the grade.
+// A failed recall is a lapse: the word starts over from the first interval and keeps its ease factor.
+func nextSchedule(schedule entity.ReviewSchedule, grade int) entity.ReviewSchedule {
+	// У слов, добавленных до появления расписания, ease factor может быть не задан
+	if schedule.EaseFactor < minEaseFactor {
+		schedule.EaseFactor = defaultEaseFactor
+	}
+
+	if grade < passingGrade {
+		// Забытым считается только слово, которое уже вспоминали
+		if schedule.Repetitions > 0 {
+			schedule.Lapses++
+		}
+
+		schedule.Repetitions = 0
+		schedule.IntervalDays = firstIntervalDays
+
+		return schedule
+	}
+
+	switch schedule.Repetitions {
+	case 0:
+		schedule.IntervalDays = firstIntervalDays
+	case 1:
+		schedule.IntervalDays = secondIntervalDays
+	default:
+		schedule.IntervalDays = int(math.Round(float64(schedule.IntervalDays) * schedule.EaseFactor))
+	}
+
+	schedule.Repetitions++
+
+	miss := float64(maxGrade - grade)
+	schedule.EaseFactor = math.Max(minEaseFactor, schedule.EaseFactor+0.1-miss*(0.08+miss*0.02))
+
+	return schedule
+}
@@ -0,0 +1,75 @@
+package review_word
+
+import (
+	"context"
+	"fmt"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+
+	"github.com/google/uuid"
+)
+
+type StorageProvider interface {
+	GetUserWordByID(ctx context.Context, wordID string, userID int) (storage.UserWord, error)
+	SaveWordReview(
+		ctx context.Context,
+		wordID string,
+		userID int,
+		reviewCount int,
+		review entity.WordReview,
+	) (storage.UserWord, error)
+}
+
+type UseCase struct {
+	storage StorageProvider
+
+	cfg *config.Reviews
+}
+
+func New(storage StorageProvider, cfg *config.Reviews) UseCase {
+	return UseCase{
+		storage: storage,
+		cfg:     cfg,
+	}
+}
+
+// ReviewWord records the recall grade of the word and schedules its next review. The word is learned while
+// its interval is at least the mastery threshold.
+func (u *UseCase) ReviewWord(ctx context.Context, wordID string, userID, grade int) (entity.UserWord, error) {
+	if _, err := uuid.Parse(wordID); err != nil {
+		return entity.UserWord{}, errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+	}
+
+	if grade < minGrade || grade > maxGrade {
+		return entity.UserWord{}, errs.New(errs.ErrDecodingJSON, fmt.Sprintf("grade must be from %d to %d", minGrade, maxGrade))
+	}
+
+	word, err := u.storage.GetUserWordByID(ctx, wordID, userID)
+	if err != nil {
+		return entity.UserWord{}, errs.Wrap("u.storage.GetUserWordByID", err)
+	}
+
+	schedule := nextSchedule(entity.ReviewSchedule{
+		EaseFactor:   word.EaseFactor,
+		IntervalDays: word.IntervalDays,
+		Repetitions:  word.Repetitions,
+		Lapses:       word.Lapses,
+	}, grade)
+
+	review := entity.WordReview{
+		Grade:    grade,
+		Schedule: schedule,
+		Learned:  schedule.IntervalDays >= u.cfg.MasteryIntervalDays,
+	}
+
+	// Расписание посчитано от прочитанного состояния: повторная отправка оценки не должна применяться дважды
+	reviewed, err := u.storage.SaveWordReview(ctx, wordID, userID, word.ReviewCount, review)
+	if err != nil {
+		return entity.UserWord{}, errs.Wrap("u.storage.SaveWordReview", err)
+	}
+
+	return reviewed.Entity(), nil
+}
@@ -3,23 +3,54 @@ package session_completer
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"strings"
+	"slices"
+	"sync"
 
 	"speech-processing-service/internal/drivers/storage"
 	"speech-processing-service/internal/entity"
 	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/fluency"
+	"speech-processing-service/internal/jsonschema"
+	"speech-processing-service/internal/reprompt"
 
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
-	promptBasis = "You are an English language assessment assistant. A student has answered three open-ended questions in English. Your task is to analyze the language level and provide structured feedback based on the text.\n\nKeep in mind:\n- The text is generated by speech-to-text API, so ignore errors related to punctuation or spelling that might have come from automatic transcription.\n- Focus on evaluating the actual language proficiency and content of the answer.\n\nBe especially attentive to grammar mistakes:/n- Only include errors that break grammar rules (tense, articles, prepositions, subject-verb agreement, word order, etc.)./n- Do NOT include stylistic or semantic issues, such as vague phrases, awkward wording, or lack of specificity — even if the sentence could be improved stylistically, if it's grammatically correct, move the suggestion to the \"rephrase_suggestions\" section.\n- Explain the grammar rule that was broken in each case./nAdapt all the explanations to scored level of English\n\nProvide the results in the following structured JSON format:\n\n{\n  \"overall_level\": \"<CEFR Level: A1, A2, B1, B2, C1, or C2>\",\n  \"top_words\": [\n    {\n      \"words\": \"<word>\",\n      \"level\": \"<A1-C2>\"\n    }\n  ],\n  \"grammar_issues\": [\n    {\n      \"sentence\": \"<sentence with grammar mistake>\",\n      \"explanation\": \"<what is wrong and what rule was violated>\",\n \"corrected_sentence\": \"correct the mistake\"    }\n  ],\n  \"rephrase_suggestions\": [\n    {\n      \"original\": \"<original sentence or part>\",\n      \"suggestion\": \"<how it can be rephrased to sound better>\"\n    }\n  ],\n  \"overall_feedback\": \"<general impression, fluency, vocabulary range, and what the user can work on. Speak directly to the user>\"\n}\n\nNow, here is the user's response to three questions:\n\n"
+	transcriptionProgressShare = 90
+
+	// categoryField is the grammar issue field with the rule category
+	categoryField = "category"
+)
+
+var (
+	// analysisSchema is the reply schema of prompt versions that don't ask for the category of grammar issues
+	analysisSchema = newAnalysisSchema(false)
+	// categorizedSchema also requires the category of every grammar issue
+	categorizedSchema = newAnalysisSchema(true)
 )
 
 type AnswersQuestionsGetter interface {
 	GetAnswerBySessionID(ctx context.Context, sessionID string) ([]storage.Answer, error)
 	GetQuestionByID(ctx context.Context, id int) (storage.Question, error)
+	SaveAnswerTranscript(ctx context.Context, answerID int, filename, transcript string, fluency []byte) error
+}
+
+type SessionsProvider interface {
+	GetSessionByID(ctx context.Context, sessionID string, userID int) (storage.Session, error)
+	MarkSessionCompleted(
+		ctx context.Context,
+		sessionID string,
+		analysis []byte,
+		prompt entity.PromptVersion,
+		metrics entity.SessionMetrics,
+		mistakes []entity.GrammarIssue,
+	) error
+}
+
+type UserSettingsGetter interface {
+	GetUserSettings(ctx context.Context, userID int) (storage.UserSettings, error)
 }
 
 type URLGetter interface {
@@ -27,41 +58,120 @@ type URLGetter interface {
 }
 
 type AudioTranscriber interface {
-	TranscribeAudio(ctx context.Context, url string) (string, error)
+	TranscribeAudio(ctx context.Context, audio entity.AudioSource) (entity.Transcription, error)
 }
 
 type TextAnalyzer interface {
-	AnalyzeText(ctx context.Context, prompt string) (string, error)
+	AnalyzeText(ctx context.Context, prompt string, schema *jsonschema.Schema) (string, error)
+}
+
+type PromptRenderer interface {
+	Resolve(prompt entity.PromptVersion) (entity.PromptVersion, error)
+	Localized(prompt entity.PromptVersion) bool
+	Categorized(prompt entity.PromptVersion) bool
+	Render(prompt entity.PromptVersion, data any) (string, error)
 }
 
 type UseCase struct {
 	logger *zap.Logger
 
 	answersGetter    AnswersQuestionsGetter
+	sessions         SessionsProvider
+	settings         UserSettingsGetter
 	urlGetter        URLGetter
 	audioTranscriber AudioTranscriber
 	textAnalyzer     TextAnalyzer
+	prompts          PromptRenderer
+
+	// concurrency limits how many answers are transcribed at the same time.
+	concurrency int
+	// retries is how many times an invalid analysis is sent back to the model.
+	retries int
 }
 
 func New(
 	logger *zap.Logger,
 	answersGetter AnswersQuestionsGetter,
+	sessions SessionsProvider,
+	settings UserSettingsGetter,
 	urlGetter URLGetter,
 	audioTranscriber AudioTranscriber,
 	textAnalyzer TextAnalyzer,
+	prompts PromptRenderer,
+	concurrency int,
+	retries int,
 ) UseCase {
 	return UseCase{
 		logger: logger,
 
 		answersGetter:    answersGetter,
+		sessions:         sessions,
+		settings:         settings,
 		urlGetter:        urlGetter,
 		audioTranscriber: audioTranscriber,
 		textAnalyzer:     textAnalyzer,
+		prompts:          prompts,
+
+		concurrency: max(concurrency, 1),
+		retries:     max(retries, 0),
 	}
 }
 
-func (u *UseCase) CompleteSession(ctx context.Context, sessionID string) (entity.AnalyzeTextResult, error) {
-	prompt := promptBasis
+// CompleteSession transcribes the session answers and analyzes them with the given prompt, by default
+// the latest version of the default prompt. Explanations are written in feedbackLanguage, by default in
+// the language from the user settings. Prompt versions that don't support the language write in English,
+// and English is recorded for them. A completed session returns its stored analysis unless reanalyze
+// is set or another prompt or language is requested; stored transcripts are reused either way.
+// progress, if not nil, receives the share of work done in percent.
+func (u *UseCase) CompleteSession(
+	ctx context.Context,
+	sessionID string,
+	userID int,
+	reanalyze bool,
+	prompt entity.PromptVersion,
+	feedbackLanguage string,
+	progress func(percent int),
+) (entity.AnalyzeTextResult, error) {
+	if progress == nil {
+		progress = func(int) {}
+	}
+
+	requested := prompt != entity.PromptVersion{}
+	if prompt.Name == "" {
+		prompt.Name = entity.DefaultAnalysisPrompt
+	}
+
+	prompt, err := u.prompts.Resolve(prompt)
+	if err != nil {
+		return entity.AnalyzeTextResult{}, errs.Wrap("u.prompts.Resolve", err)
+	}
+
+	feedbackLanguage, err = u.feedbackLanguage(ctx, userID, feedbackLanguage)
+	if err != nil {
+		return entity.AnalyzeTextResult{}, err
+	}
+
+	// Шаблон без языка пишет по-английски, и анализ должен сохраниться с тем языком, на котором он написан
+	if !u.prompts.Localized(prompt) {
+		feedbackLanguage = entity.DefaultFeedbackLanguage
+	}
+
+	session, err := u.sessions.GetSessionByID(ctx, sessionID, userID)
+	if err != nil {
+		return entity.AnalyzeTextResult{}, errs.Wrap("u.sessions.GetSessionByID", err)
+	}
+
+	if !reanalyze && session.Status == entity.SessionStatusCompleted && session.Analysis != nil {
+		var stored entity.AnalyzeTextResult
+		switch err := json.Unmarshal([]byte(*session.Analysis), &stored); {
+		case err != nil:
+			u.logger.Warn("stored analysis is corrupted, regenerating", zap.String("session_id", sessionID))
+		case languageOf(stored) != feedbackLanguage:
+			u.logger.Info("feedback language changed, regenerating", zap.String("session_id", sessionID))
+		case !requested || (stored.Prompt != nil && *stored.Prompt == prompt):
+			return stored, nil
+		}
+	}
 
 	answersDB, err := u.answersGetter.GetAnswerBySessionID(ctx, sessionID)
 	if err != nil {
@@ -72,54 +182,316 @@ func (u *UseCase) CompleteSession(ctx context.Context, sessionID string) (entity
 		return entity.AnalyzeTextResult{}, errs.New(errs.ErrNotFound, "answers not found")
 	}
 
-	for _, answerDB := range answersDB {
-		url, err := u.urlGetter.GenerateUrl(ctx, answerDB.Filename, true)
-		if err != nil {
-			u.logger.Error("u.urlGetter.GenerateURl", zap.Error(err))
-			return entity.AnalyzeTextResult{}, err
-		}
+	questions := make([]string, len(answersDB))
+	transcriptions := make([]string, len(answersDB))
+	fluencies := make([]*entity.AnswerFluency, len(answersDB))
 
-		question, err := u.answersGetter.GetQuestionByID(ctx, answerDB.QuestionID)
-		if err != nil {
-			u.logger.Error("u.answersGetter.GetQuestionByID", zap.Error(err))
+	var (
+		progressMu sync.Mutex
+		done       int
+	)
+
+	// Первая ошибка отменяет groupCtx, и остальные транскрибации прерываются
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(u.concurrency)
+
+	for i, answerDB := range answersDB {
+		group.Go(func() error {
+			question, err := u.answersGetter.GetQuestionByID(groupCtx, answerDB.QuestionID)
+			if err != nil {
+				u.logger.Error("u.answersGetter.GetQuestionByID", zap.Error(err))
+
+				return err
+			}
 
-			return entity.AnalyzeTextResult{}, err
+			transcription, fluency, err := u.transcribeAnswer(groupCtx, answerDB)
+			if err != nil {
+				return err
+			}
+
+			questions[i] = question.Question
+			transcriptions[i] = transcription
+			fluencies[i] = fluency
+
+			// Транскрибация - основная часть работы, анализ оставляет последние проценты
+			progressMu.Lock()
+			done++
+			progress(done * transcriptionProgressShare / len(answersDB))
+			progressMu.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return entity.AnalyzeTextResult{}, err
+	}
+
+	data := entity.AnalysisPromptData{
+		Answers:          make([]entity.PromptAnswer, len(answersDB)),
+		FeedbackLanguage: entity.FeedbackLanguages[feedbackLanguage],
+	}
+
+	// Порядок вопросов в промпте совпадает с порядком ответов
+	for i, answerDB := range answersDB {
+		data.Answers[i] = entity.PromptAnswer{
+			ID:         answerDB.ID,
+			Question:   questions[i],
+			Transcript: transcriptions[i],
 		}
+	}
 
-		transcription, err := u.audioTranscriber.TranscribeAudio(ctx, url)
-		if err != nil {
-			u.logger.Error("u.audioTranscriber.TranscribeAudio", zap.Error(err))
+	rendered, err := u.prompts.Render(prompt, data)
+	if err != nil {
+		u.logger.Error("u.prompts.Render", zap.Error(err))
+
+		return entity.AnalyzeTextResult{}, err
+	}
+
+	answerIDs := make([]int, len(answersDB))
+	for i, answerDB := range answersDB {
+		answerIDs[i] = answerDB.ID
+	}
+
+	categorized := u.prompts.Categorized(prompt)
+
+	result, err := u.analyze(ctx, rendered, answerIDs, categorized)
+	if err != nil {
+		return entity.AnalyzeTextResult{}, err
+	}
+
+	result.Answers = arrangeAnswers(result.Answers, answersDB, questions, transcriptions)
+
+	result.GrammarIssues = []entity.GrammarIssue{}
+	for _, answer := range result.Answers {
+		result.GrammarIssues = append(result.GrammarIssues, answer.GrammarIssues...)
+	}
 
-			return entity.AnalyzeTextResult{}, err
+	result.Fluency = []entity.AnswerFluency{}
+	for _, fluency := range fluencies {
+		if fluency != nil {
+			result.Fluency = append(result.Fluency, *fluency)
 		}
+	}
+
+	result.Prompt = &prompt
+	result.FeedbackLanguage = feedbackLanguage
+
+	analysis, err := json.Marshal(result)
+	if err != nil {
+		return entity.AnalyzeTextResult{}, errs.New(errs.ErrMarshalingJSON, err.Error())
+	}
+
+	metrics := sessionMetrics(result, len(answersDB))
 
-		prompt += fmt.Sprintf("Question: %s\nAnswer: %s\n", question.Question, transcription)
+	// Промпты без категорий не пополняют каталог ошибок, старые записи сессии при этом удаляются
+	var mistakes []entity.GrammarIssue
+	if categorized {
+		mistakes = result.GrammarIssues
 	}
 
-	resultStr, err := u.textAnalyzer.AnalyzeText(ctx, prompt)
+	err = u.sessions.MarkSessionCompleted(ctx, sessionID, analysis, prompt, metrics, mistakes)
 	if err != nil {
-		u.logger.Error("u.textAnalyzer.AnalyzeText", zap.Error(err))
+		u.logger.Error("u.sessions.MarkSessionCompleted", zap.Error(err))
 
 		return entity.AnalyzeTextResult{}, err
 	}
 
-	startIndex := strings.Index(resultStr, "{")
-	if startIndex != -1 {
-		resultStr = resultStr[startIndex:]
+	return result, nil
+}
+
+// transcribeAnswer returns the stored transcript and fluency of the answer or transcribes and stores them.
+// fluency is nil when the provider gave no word timings.
+func (u *UseCase) transcribeAnswer(ctx context.Context, answerDB storage.Answer) (string, *entity.AnswerFluency, error) {
+	if answerDB.Transcript != nil {
+		if answerDB.Fluency == nil {
+			return *answerDB.Transcript, nil, nil
+		}
+
+		var fluency entity.AnswerFluency
+		if err := json.Unmarshal([]byte(*answerDB.Fluency), &fluency); err != nil {
+			u.logger.Warn("stored fluency is corrupted", zap.Int("answer_id", answerDB.ID), zap.Error(err))
+
+			return *answerDB.Transcript, nil, nil
+		}
+
+		return *answerDB.Transcript, &fluency, nil
+	}
+
+	// Нормализованная запись без тишины по краям распознается точнее оригинала
+	objectName := answerDB.Filename
+	if answerDB.NormalizedFilename != nil {
+		objectName = *answerDB.NormalizedFilename
+	}
+
+	url, err := u.urlGetter.GenerateUrl(ctx, objectName, true)
+	if err != nil {
+		u.logger.Error("u.urlGetter.GenerateURl", zap.Error(err))
+
+		return "", nil, err
+	}
+
+	transcription, err := u.audioTranscriber.TranscribeAudio(ctx, entity.AudioSource{
+		ObjectName: objectName,
+		URL:        url,
+	})
+	if err != nil {
+		u.logger.Error("u.audioTranscriber.TranscribeAudio", zap.Error(err))
+
+		return "", nil, err
+	}
+
+	metrics := fluency.Compute(answerDB.ID, answerDB.QuestionID, transcription.Words)
+
+	var fluencyJSON []byte
+	if metrics != nil {
+		fluencyJSON, err = json.Marshal(metrics)
+		if err != nil {
+			return "", nil, errs.New(errs.ErrMarshalingJSON, err.Error())
+		}
+	}
+
+	// Если ученик перезаписал ответ во время анализа, задача завершится ошибкой и повторится с новой записью
+	err = u.answersGetter.SaveAnswerTranscript(ctx, answerDB.ID, answerDB.Filename, transcription.Transcript, fluencyJSON)
+	if err != nil {
+		u.logger.Error("u.answersGetter.SaveAnswerTranscript", zap.Error(err))
+
+		return "", nil, err
+	}
+
+	return transcription.Transcript, metrics, nil
+}
+
+// sessionMetrics extracts the numbers tracked across sessions from the analysis.
+func sessionMetrics(result entity.AnalyzeTextResult, answersCount int) entity.SessionMetrics {
+	metrics := entity.SessionMetrics{
+		OverallLevel:  result.OverallLevel,
+		AnswersCount:  answersCount,
+		GrammarIssues: len(result.GrammarIssues),
+		Vocabulary:    make(map[string]int),
+	}
+
+	for _, word := range result.TopWords {
+		metrics.Vocabulary[word.Level]++
+	}
+
+	if len(result.Fluency) > 0 {
+		var wordsPerMinute, fillerRate float64
+		for _, fluency := range result.Fluency {
+			wordsPerMinute += fluency.WordsPerMinute
+			fillerRate += fluency.FillerRate
+		}
+
+		wordsPerMinute /= float64(len(result.Fluency))
+		fillerRate /= float64(len(result.Fluency))
+
+		metrics.WordsPerMinute = &wordsPerMinute
+		metrics.FillerRate = &fillerRate
+	}
+
+	return metrics
+}
+
+// feedbackLanguage returns the requested language or, when it is not set, the one from the user settings.
+func (u *UseCase) feedbackLanguage(ctx context.Context, userID int, requested string) (string, error) {
+	language := requested
+	if language == "" {
+		settings, err := u.settings.GetUserSettings(ctx, userID)
+		if err != nil {
+			return "", errs.Wrap("u.settings.GetUserSettings", err)
+		}
+
+		language = settings.FeedbackLanguage
+	}
+
+	if _, ok := entity.FeedbackLanguages[language]; !ok {
+		return "", errs.New(errs.ErrDecodingJSON, "unsupported feedback_language: "+language)
+	}
+
+	return language, nil
+}
+
+// languageOf returns the feedback language of a stored analysis; analyses made before the setting are in English.
+func languageOf(result entity.AnalyzeTextResult) string {
+	if result.FeedbackLanguage == "" {
+		return entity.DefaultFeedbackLanguage
+	}
+
+	return result.FeedbackLanguage
+}
+
+// arrangeAnswers puts the validated feedback in the order of the session answers and adds
+// the questions and transcripts the model doesn't return.
+func arrangeAnswers(
+	feedback []entity.AnswerFeedback,
+	answersDB []storage.Answer,
+	questions, transcriptions []string,
+) []entity.AnswerFeedback {
+	byID := make(map[int]entity.AnswerFeedback, len(feedback))
+	for _, answer := range feedback {
+		byID[answer.AnswerID] = answer
 	}
 
-	endIndex := strings.LastIndex(resultStr, "}")
-	if endIndex != -1 {
-		resultStr = resultStr[:endIndex+1]
+	arranged := make([]entity.AnswerFeedback, 0, len(answersDB))
+	for i, answerDB := range answersDB {
+		answer := byID[answerDB.ID]
+		answer.AnswerID = answerDB.ID
+		answer.QuestionID = answerDB.QuestionID
+		answer.Question = questions[i]
+		answer.Transcript = transcriptions[i]
+
+		if answer.GrammarIssues == nil {
+			answer.GrammarIssues = []entity.GrammarIssue{}
+		}
+		for j := range answer.GrammarIssues {
+			answer.GrammarIssues[j].AnswerID = answerDB.ID
+		}
+
+		arranged = append(arranged, answer)
 	}
 
-	var result entity.AnalyzeTextResult
-	err = json.Unmarshal([]byte(resultStr), &result)
+	return arranged
+}
+
+// analyze asks the model for the analysis in JSON mode and re-prompts it with the validation
+// errors until the reply is valid or retries are exhausted. categorized requires a category for every
+// grammar issue; prompts that don't ask for it may leave it empty.
+func (u *UseCase) analyze(ctx context.Context, prompt string, answerIDs []int, categorized bool) (entity.AnalyzeTextResult, error) {
+	schema := analysisSchema
+	if categorized {
+		schema = categorizedSchema
+	}
+
+	result, err := reprompt.Ask(ctx, u.logger, u.textAnalyzer, prompt, schema, u.retries,
+		func(reply string) (entity.AnalyzeTextResult, []string) {
+			return parseResult(reply, answerIDs, categorized)
+		},
+	)
 	if err != nil {
-		fmt.Println("Error unmarshaling JSON:", err)
+		u.logger.Error("reprompt.Ask", zap.Error(err))
 
-		return entity.AnalyzeTextResult{}, errs.New(errs.ErrDecodingJSON, err.Error())
+		return entity.AnalyzeTextResult{}, errs.Wrap("reprompt.Ask", err)
 	}
 
 	return result, nil
 }
+
+// newAnalysisSchema derives the reply schema from the analysis. The category of grammar issues is required
+// when the prompt asks for it and left out otherwise, so the model isn't pushed to invent one.
+func newAnalysisSchema(categorized bool) *jsonschema.Schema {
+	schema := jsonschema.For(entity.AnalyzeTextResult{})
+	issue := schema.Properties["answers"].Items.Properties["grammar_issues"].Items
+
+	if categorized {
+		issue.Required = append(issue.Required, categoryField)
+
+		return schema
+	}
+
+	delete(issue.Properties, categoryField)
+	issue.PropertyOrdering = slices.DeleteFunc(issue.PropertyOrdering, func(name string) bool {
+		return name == categoryField
+	})
+
+	return schema
+}
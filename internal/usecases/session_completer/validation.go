@@ -0,0 +1,133 @@
+package session_completer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"speech-processing-service/internal/entity"
+)
+
+// parseResult extracts the analysis from the model reply and lists everything wrong with it.
+// answerIDs are the answers that must get feedback; categorized requires a category for every grammar issue.
+func parseResult(reply string, answerIDs []int, categorized bool) (entity.AnalyzeTextResult, []string) {
+	// В JSON-режиме ответ уже чистый, но модели без него оборачивают JSON в текст
+	startIndex := strings.Index(reply, "{")
+	if startIndex != -1 {
+		reply = reply[startIndex:]
+	}
+
+	endIndex := strings.LastIndex(reply, "}")
+	if endIndex != -1 {
+		reply = reply[:endIndex+1]
+	}
+
+	var result entity.AnalyzeTextResult
+	if err := json.Unmarshal([]byte(reply), &result); err != nil {
+		return entity.AnalyzeTextResult{}, []string{"reply is not valid JSON: " + err.Error()}
+	}
+
+	return result, validateResult(&result, answerIDs, categorized)
+}
+
+func validateResult(result *entity.AnalyzeTextResult, answerIDs []int, categorized bool) []string {
+	var problems []string
+
+	if !entity.IsCEFRLevel(result.OverallLevel) {
+		problems = append(problems, fmt.Sprintf(
+			"overall_level %q must be one of %s", result.OverallLevel, strings.Join(entity.CEFRLevels, ", "),
+		))
+	}
+
+	if strings.TrimSpace(result.OverallFeedback) == "" {
+		problems = append(problems, "overall_feedback must not be empty")
+	}
+
+	for i, word := range result.TopWords {
+		if strings.TrimSpace(word.Words) == "" {
+			problems = append(problems, fmt.Sprintf("top_words[%d].words must not be empty", i))
+		}
+
+		if !entity.IsCEFRLevel(word.Level) {
+			problems = append(problems, fmt.Sprintf("top_words[%d].level %q is not a CEFR level", i, word.Level))
+		}
+	}
+
+	problems = append(problems, validateAnswers(result.Answers, answerIDs, categorized)...)
+
+	for i, suggestion := range result.RephraseSuggestions {
+		if strings.TrimSpace(suggestion.Original) == "" || strings.TrimSpace(suggestion.Suggestion) == "" {
+			problems = append(problems, fmt.Sprintf("rephrase_suggestions[%d] must have original and suggestion", i))
+		}
+	}
+
+	return problems
+}
+
+func validateAnswers(answers []entity.AnswerFeedback, answerIDs []int, categorized bool) []string {
+	var problems []string
+
+	expected := make(map[int]bool, len(answerIDs))
+	for _, id := range answerIDs {
+		expected[id] = true
+	}
+
+	seen := make(map[int]bool, len(answers))
+	for i, answer := range answers {
+		switch {
+		case !expected[answer.AnswerID]:
+			problems = append(problems, fmt.Sprintf("answers[%d].answer_id %d is not one of the given answers", i, answer.AnswerID))
+		case seen[answer.AnswerID]:
+			problems = append(problems, fmt.Sprintf("answers[%d].answer_id %d is repeated", i, answer.AnswerID))
+		}
+		seen[answer.AnswerID] = true
+
+		if !isRelevance(answer.Relevance) {
+			problems = append(problems, fmt.Sprintf(
+				"answers[%d].relevance %q must be one of %s, %s, %s",
+				i, answer.Relevance, entity.RelevanceRelevant, entity.RelevancePartial, entity.RelevanceOffTopic,
+			))
+		}
+
+		if !entity.IsCEFRLevel(answer.Level) {
+			problems = append(problems, fmt.Sprintf("answers[%d].level %q is not a CEFR level", i, answer.Level))
+		}
+
+		if strings.TrimSpace(answer.Feedback) == "" {
+			problems = append(problems, fmt.Sprintf("answers[%d].feedback must not be empty", i))
+		}
+
+		for j, issue := range answer.GrammarIssues {
+			if strings.TrimSpace(issue.Sentence) == "" || strings.TrimSpace(issue.CorrectedSentence) == "" {
+				problems = append(problems, fmt.Sprintf(
+					"answers[%d].grammar_issues[%d] must have sentence and corrected_sentence", i, j,
+				))
+			}
+
+			// Промпты без категорий её не просят, и такие ошибки не попадают в каталог
+			if categorized && !entity.IsGrammarCategory(issue.Category) {
+				problems = append(problems, fmt.Sprintf(
+					"answers[%d].grammar_issues[%d].category %q must be one of %s",
+					i, j, issue.Category, strings.Join(entity.GrammarCategories, ", "),
+				))
+			}
+		}
+	}
+
+	for _, id := range answerIDs {
+		if !seen[id] {
+			problems = append(problems, fmt.Sprintf("answers must include answer_id %d", id))
+		}
+	}
+
+	return problems
+}
+
+func isRelevance(relevance string) bool {
+	switch relevance {
+	case entity.RelevanceRelevant, entity.RelevancePartial, entity.RelevanceOffTopic:
+		return true
+	}
+
+	return false
+}
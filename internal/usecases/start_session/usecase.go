@@ -11,7 +11,7 @@ import (
 )
 
 type SessionsCreator interface {
-	CreateSession(ctx context.Context, sessionID string, topicID int) error
+	CreateSession(ctx context.Context, sessionID string, userID, topicID int) error
 }
 
 type QuestionsGetter interface {
@@ -37,8 +37,8 @@ func New(
 	}
 }
 
-func (u *Usecase) StartSession(ctx context.Context, sessionID string, topicID int) (entity.Session, error) {
-	err := u.sessionsCreator.CreateSession(ctx, sessionID, topicID)
+func (u *Usecase) StartSession(ctx context.Context, sessionID string, userID, topicID int) (entity.Session, error) {
+	err := u.sessionsCreator.CreateSession(ctx, sessionID, userID, topicID)
 	if err != nil {
 		return entity.Session{}, errs.Wrap("u.sessionsCreator.CreateSession", err)
 	}
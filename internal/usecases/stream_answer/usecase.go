@@ -0,0 +1,231 @@
+package stream_answer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"speech-processing-service/internal/config"
+	"speech-processing-service/internal/drivers/apis/stt"
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+	"speech-processing-service/internal/fluency"
+	"speech-processing-service/internal/usecases/attach_answer_to_session"
+
+	"go.uber.org/zap"
+)
+
+type SessionGetter interface {
+	GetSessionByID(ctx context.Context, sessionID string, userID int) (storage.Session, error)
+}
+
+type StreamTranscriber interface {
+	OpenStream(ctx context.Context) (stt.Stream, error)
+}
+
+type RecordingAttacher interface {
+	AttachRecording(
+		ctx context.Context,
+		sessionID string,
+		userID int,
+		questionID int,
+		file attach_answer_to_session.AudioFile,
+		size int64,
+	) (int, string, error)
+}
+
+type TranscriptSaver interface {
+	SaveAnswerTranscript(ctx context.Context, answerID int, filename, transcript string, fluency []byte) error
+}
+
+type UseCase struct {
+	logger *zap.Logger
+
+	sessionGetter     SessionGetter
+	streamTranscriber StreamTranscriber
+	recordingAttacher RecordingAttacher
+	transcriptSaver   TranscriptSaver
+
+	limits *config.Answers
+}
+
+func New(
+	logger *zap.Logger,
+	sessionGetter SessionGetter,
+	streamTranscriber StreamTranscriber,
+	recordingAttacher RecordingAttacher,
+	transcriptSaver TranscriptSaver,
+	limits *config.Answers,
+) UseCase {
+	return UseCase{
+		logger:            logger,
+		sessionGetter:     sessionGetter,
+		streamTranscriber: streamTranscriber,
+		recordingAttacher: recordingAttacher,
+		transcriptSaver:   transcriptSaver,
+		limits:            limits,
+	}
+}
+
+// StreamAnswer relays the learner audio to the streaming STT, sends the recognition results back and,
+// when the learner finishes or disconnects, stores the recording and its transcript as the answer.
+// The recording is spooled to a temporary file and limited by the answer size and duration limits.
+// recv returns the next audio chunk and io.EOF when the learner has finished; send delivers results
+// to the learner. It returns the answer ID.
+func (u *UseCase) StreamAnswer(
+	ctx context.Context,
+	sessionID string,
+	userID int,
+	questionID int,
+	recv func() ([]byte, error),
+	send func(update entity.TranscriptUpdate) error,
+) (int, error) {
+	if u.streamTranscriber == nil {
+		return 0, errs.New(errs.ErrUseCaseExecution, "the stt provider does not support streaming")
+	}
+
+	session, err := u.sessionGetter.GetSessionByID(ctx, sessionID, userID)
+	if err != nil {
+		return 0, errs.Wrap("u.sessionGetter.GetSessionByID", err)
+	}
+
+	if session.Status == entity.SessionStatusCompleted {
+		return 0, errs.New(errs.ErrSessionCompleted, "answers can't be changed after completion")
+	}
+
+	stream, err := u.streamTranscriber.OpenStream(ctx)
+	if err != nil {
+		return 0, errs.Wrap("u.streamTranscriber.OpenStream", err)
+	}
+	defer stream.Close()
+
+	recording, err := os.CreateTemp("", "stream-answer-*")
+	if err != nil {
+		return 0, errs.New(errs.ErrUseCaseExecution, "os.CreateTemp: "+err.Error())
+	}
+	defer os.Remove(recording.Name())
+	defer recording.Close()
+
+	var size int64
+
+	relayErr := make(chan error, 1)
+	go func() {
+		var err error
+		size, err = u.relay(recv, stream, recording)
+		relayErr <- err
+
+		// Закрытие потока прерывает ожидание результатов в stream.Recv
+		if err != nil {
+			stream.Close()
+		}
+	}()
+
+	var (
+		finals []string
+		words  []entity.TranscribedWord
+	)
+
+	for {
+		update, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			// Ждать relay нельзя: он может быть заблокирован чтением от ученика
+			select {
+			case relayError := <-relayErr:
+				if relayError != nil {
+					return 0, relayError
+				}
+			default:
+			}
+
+			return 0, errs.Wrap("stream.Recv", err)
+		}
+
+		if update.IsFinal {
+			finals = append(finals, update.Transcript)
+			words = append(words, update.Words...)
+		}
+
+		// Ученик мог отключиться, но запись и транскрипт все равно сохраняются
+		if err := send(update); err != nil {
+			u.logger.Warn("send", zap.Error(err))
+		}
+	}
+
+	if err := <-relayErr; err != nil {
+		return 0, err
+	}
+
+	if size == 0 {
+		return 0, errs.New(errs.ErrInvalidAudio, "no audio received")
+	}
+
+	if _, err := recording.Seek(0, io.SeekStart); err != nil {
+		return 0, errs.New(errs.ErrUseCaseExecution, "recording.Seek: "+err.Error())
+	}
+
+	answerID, filename, err := u.recordingAttacher.AttachRecording(ctx, sessionID, userID, questionID, recording, size)
+	if err != nil {
+		return 0, errs.Wrap("u.recordingAttacher.AttachRecording", err)
+	}
+
+	transcript := strings.Join(strings.Fields(strings.Join(finals, " ")), " ")
+
+	var fluencyJSON []byte
+	if metrics := fluency.Compute(answerID, questionID, words); metrics != nil {
+		fluencyJSON, err = json.Marshal(metrics)
+		if err != nil {
+			return 0, errs.New(errs.ErrMarshalingJSON, err.Error())
+		}
+	}
+
+	if err := u.transcriptSaver.SaveAnswerTranscript(ctx, answerID, filename, transcript, fluencyJSON); err != nil {
+		return 0, errs.Wrap("u.transcriptSaver.SaveAnswerTranscript", err)
+	}
+
+	return answerID, nil
+}
+
+// relay copies the learner audio to the STT stream and into recording until the learner finishes and returns
+// the recorded size. Live audio arrives in real time, so the recording can't outlast the wall clock.
+func (u *UseCase) relay(recv func() ([]byte, error), stream stt.Stream, recording io.Writer) (int64, error) {
+	var size int64
+	started := time.Now()
+
+	for {
+		chunk, err := recv()
+		if errors.Is(err, io.EOF) {
+			return size, stream.CloseSend()
+		}
+
+		if err != nil {
+			return size, errs.Wrap("recv", err)
+		}
+
+		if size+int64(len(chunk)) > u.limits.MaxSize {
+			return size, errs.New(errs.ErrInvalidAudio, fmt.Sprintf("recording is larger than %d bytes", u.limits.MaxSize))
+		}
+
+		if time.Since(started) > u.limits.MaxDuration {
+			return size, errs.New(errs.ErrInvalidAudio, "recording is longer than "+u.limits.MaxDuration.String())
+		}
+
+		if _, err := recording.Write(chunk); err != nil {
+			return size, errs.New(errs.ErrUseCaseExecution, "recording.Write: "+err.Error())
+		}
+		size += int64(len(chunk))
+
+		if err := stream.Send(chunk); err != nil {
+			return size, errs.Wrap("stream.Send", err)
+		}
+	}
+}
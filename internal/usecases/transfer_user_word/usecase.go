@@ -0,0 +1,64 @@
+package transfer_user_word
+
+import (
+	"context"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+
+	"github.com/google/uuid"
+)
+
+type StorageProvider interface {
+	MoveUserWord(ctx context.Context, collectionID, wordID, targetID string, userID int) (storage.UserWord, error)
+	CopyUserWord(ctx context.Context, collectionID, wordID, targetID string, userID int) (storage.UserWord, error)
+}
+
+type UseCase struct {
+	storage StorageProvider
+}
+
+func New(storage StorageProvider) UseCase {
+	return UseCase{
+		storage: storage,
+	}
+}
+
+// TransferWord moves the word to another collection of the user, or copies it when asCopy is set.
+// A moved word keeps its review schedule, a copy is studied from the beginning.
+func (u *UseCase) TransferWord(
+	ctx context.Context,
+	collectionID, wordID, targetID string,
+	userID int,
+	asCopy bool,
+) (entity.UserWord, error) {
+	for _, id := range []string{collectionID, wordID, targetID} {
+		if _, err := uuid.Parse(id); err != nil {
+			return entity.UserWord{}, errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+		}
+	}
+
+	if collectionID == targetID {
+		return entity.UserWord{}, errs.New(errs.ErrDecodingJSON, "target collection must differ from the source one")
+	}
+
+	var (
+		word storage.UserWord
+		err  error
+	)
+
+	if asCopy {
+		word, err = u.storage.CopyUserWord(ctx, collectionID, wordID, targetID, userID)
+		if err != nil {
+			return entity.UserWord{}, errs.Wrap("u.storage.CopyUserWord", err)
+		}
+	} else {
+		word, err = u.storage.MoveUserWord(ctx, collectionID, wordID, targetID, userID)
+		if err != nil {
+			return entity.UserWord{}, errs.Wrap("u.storage.MoveUserWord", err)
+		}
+	}
+
+	return word.Entity(), nil
+}
@@ -0,0 +1,51 @@
+package update_user_settings
+
+import (
+	"context"
+	"time"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+)
+
+type StorageProvider interface {
+	UpdateUserSettings(ctx context.Context, userID int, update entity.UserSettingsUpdate) (storage.UserSettings, error)
+}
+
+type UseCase struct {
+	storage StorageProvider
+}
+
+func New(storage StorageProvider) UseCase {
+	return UseCase{
+		storage: storage,
+	}
+}
+
+// UpdateSettings changes the given settings and returns all of them.
+func (u *UseCase) UpdateSettings(ctx context.Context, userID int, update entity.UserSettingsUpdate) (entity.UserSettings, error) {
+	if update.FeedbackLanguage != nil {
+		if _, ok := entity.FeedbackLanguages[*update.FeedbackLanguage]; !ok {
+			return entity.UserSettings{}, errs.New(errs.ErrDecodingJSON, "unsupported feedback_language: "+*update.FeedbackLanguage)
+		}
+	}
+
+	if update.TimeZone != nil {
+		// Пустое имя и Local LoadLocation принимает, но в Postgres они не означают конкретный пояс
+		_, err := time.LoadLocation(*update.TimeZone)
+		if err != nil || *update.TimeZone == "" || *update.TimeZone == "Local" {
+			return entity.UserSettings{}, errs.New(errs.ErrDecodingJSON, "unknown time_zone: "+*update.TimeZone)
+		}
+	}
+
+	settings, err := u.storage.UpdateUserSettings(ctx, userID, update)
+	if err != nil {
+		return entity.UserSettings{}, errs.Wrap("u.storage.UpdateUserSettings", err)
+	}
+
+	return entity.UserSettings{
+		FeedbackLanguage: settings.FeedbackLanguage,
+		TimeZone:         settings.TimeZone,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package update_user_word
+
+import (
+	"context"
+	"strings"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+
+	"github.com/google/uuid"
+)
+
+type StorageProvider interface {
+	UpdateUserWord(
+		ctx context.Context,
+		collectionID, wordID string,
+		userID int,
+		update entity.UserWordUpdate,
+	) (storage.UserWord, error)
+}
+
+type UseCase struct {
+	storage StorageProvider
+}
+
+func New(storage StorageProvider) UseCase {
+	return UseCase{
+		storage: storage,
+	}
+}
+
+// UpdateWord fixes the word, translation or example of a word in the collection.
+func (u *UseCase) UpdateWord(
+	ctx context.Context,
+	collectionID, wordID string,
+	userID int,
+	update entity.UserWordUpdate,
+) (entity.UserWord, error) {
+	if _, err := uuid.Parse(collectionID); err != nil {
+		return entity.UserWord{}, errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+	}
+
+	if _, err := uuid.Parse(wordID); err != nil {
+		return entity.UserWord{}, errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+	}
+
+	if update.Word == nil && update.Translation == nil && update.Example == nil {
+		return entity.UserWord{}, errs.New(errs.ErrDecodingJSON, "nothing to update")
+	}
+
+	// Слово и перевод обязательны, поэтому их нельзя очистить, в отличие от примера
+	if update.Word != nil && strings.TrimSpace(*update.Word) == "" {
+		return entity.UserWord{}, errs.New(errs.ErrDecodingJSON, "word must not be empty")
+	}
+
+	if update.Translation != nil && strings.TrimSpace(*update.Translation) == "" {
+		return entity.UserWord{}, errs.New(errs.ErrDecodingJSON, "translation must not be empty")
+	}
+
+	word, err := u.storage.UpdateUserWord(ctx, collectionID, wordID, userID, update)
+	if err != nil {
+		return entity.UserWord{}, errs.Wrap("u.storage.UpdateUserWord", err)
+	}
+
+	return word.Entity(), nil
+}
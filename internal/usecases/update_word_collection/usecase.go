@@ -0,0 +1,133 @@
+package update_word_collection
+
+import (
+	"context"
+	"mime/multipart"
+	"strings"
+
+	"speech-processing-service/internal/drivers/storage"
+	"speech-processing-service/internal/entity"
+	"speech-processing-service/internal/errs"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type StorageProvider interface {
+	UpdateWordCollection(ctx context.Context, collectionID string, userID int, name, imagePath *string) (storage.WordCollection, string, error)
+}
+
+type ImageStorage interface {
+	UploadFile(ctx context.Context, file *multipart.File, header *multipart.FileHeader, folder string) (string, error)
+	DeleteFile(ctx context.Context, filename string) error
+}
+
+type URLGetter interface {
+	GenerateURL(ctx context.Context, filename string) (string, error)
+}
+
+type UseCase struct {
+	logger    *zap.Logger
+	storage   StorageProvider
+	images    ImageStorage
+	urlGetter URLGetter
+}
+
+func New(logger *zap.Logger, storage StorageProvider, images ImageStorage, urlGetter URLGetter) UseCase {
+	return UseCase{
+		logger:    logger,
+		storage:   storage,
+		images:    images,
+		urlGetter: urlGetter,
+	}
+}
+
+// UpdateCollection renames the collection and replaces or removes its cover image.
+// A nil name keeps the current one; the previous image object is deleted once it is no longer used.
+func (u *UseCase) UpdateCollection(
+	ctx context.Context,
+	collectionID string,
+	userID int,
+	name *string,
+	imageFile *multipart.File,
+	imageHeader *multipart.FileHeader,
+	removeImage bool,
+) (entity.WordCollection, error) {
+	if _, err := uuid.Parse(collectionID); err != nil {
+		return entity.WordCollection{}, errs.New(errs.ErrTypeMustBeUUID, "uuid.Parse: "+err.Error())
+	}
+
+	hasImage := imageFile != nil && imageHeader != nil
+
+	if name != nil {
+		trimmed := strings.TrimSpace(*name)
+		if trimmed == "" {
+			return entity.WordCollection{}, errs.New(errs.ErrDecodingJSON, "name must not be empty")
+		}
+		name = &trimmed
+	}
+
+	if hasImage && removeImage {
+		return entity.WordCollection{}, errs.New(errs.ErrDecodingJSON, "image and remove_image are mutually exclusive")
+	}
+
+	if name == nil && !hasImage && !removeImage {
+		return entity.WordCollection{}, errs.New(errs.ErrDecodingJSON, "nothing to update")
+	}
+
+	var imagePath *string
+	if removeImage {
+		empty := ""
+		imagePath = &empty
+	}
+
+	// Новое изображение загружаем до транзакции, чтобы не держать блокировку строки во время загрузки
+	if hasImage {
+		uploadedPath, err := u.images.UploadFile(ctx, imageFile, imageHeader, "collections")
+		if err != nil {
+			return entity.WordCollection{}, errs.Wrap("u.images.UploadFile", err)
+		}
+		imagePath = &uploadedPath
+	}
+
+	collection, replacedImage, err := u.storage.UpdateWordCollection(ctx, collectionID, userID, name, imagePath)
+	if err != nil {
+		// Коллекция не изменилась, загруженный объект больше никому не нужен
+		if hasImage {
+			if err := u.images.DeleteFile(ctx, *imagePath); err != nil {
+				u.logger.Error("u.images.DeleteFile", zap.String("object", *imagePath), zap.Error(err))
+			}
+		}
+
+		return entity.WordCollection{}, errs.Wrap("u.storage.UpdateWordCollection", err)
+	}
+
+	if replacedImage != "" {
+		if err := u.images.DeleteFile(ctx, replacedImage); err != nil {
+			u.logger.Error("u.images.DeleteFile", zap.String("object", replacedImage), zap.Error(err))
+		}
+	}
+
+	var imageURL string
+	if collection.ImagePath != "" {
+		url, err := u.urlGetter.GenerateURL(ctx, collection.ImagePath)
+		if err != nil {
+			return entity.WordCollection{}, errs.Wrap("u.urlGetter.GenerateURL", err)
+		}
+		imageURL = url
+	}
+
+	return entity.WordCollection{
+		ID:                collection.ID.String(),
+		UserID:            collection.UserID,
+		Name:              collection.Name,
+		ImageURL:          imageURL,
+		TotalWordsCount:   collection.TotalWordsCount,
+		LearnedWordsCount: collection.LearnedWordsCount,
+		CurrentStreakDays: collection.CurrentStreakDays,
+		LongestStreakDays: collection.LongestStreakDays,
+		LastStudiedAt:     collection.LastStudiedAt,
+		CreatedAt:         collection.CreatedAt,
+		UpdatedAt:         collection.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE sessions ADD COLUMN user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE sessions ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'in_progress'; -- in_progress, completed
+ALTER TABLE sessions ADD COLUMN analysis JSONB;
+ALTER TABLE sessions ADD COLUMN created_at TIMESTAMP DEFAULT NOW();
+ALTER TABLE sessions ADD COLUMN completed_at TIMESTAMP;
+
+CREATE INDEX idx_sessions_user_created ON sessions(user_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_sessions_user_created;
+ALTER TABLE sessions DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE sessions DROP COLUMN IF EXISTS created_at;
+ALTER TABLE sessions DROP COLUMN IF EXISTS analysis;
+ALTER TABLE sessions DROP COLUMN IF EXISTS status;
+ALTER TABLE sessions DROP COLUMN IF EXISTS user_id;
+
+-- +goose StatementEnd
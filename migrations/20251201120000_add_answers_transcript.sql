@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE answers ADD COLUMN transcript TEXT;
+ALTER TABLE answers ADD COLUMN transcribed_at TIMESTAMP;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE answers DROP COLUMN IF EXISTS transcribed_at;
+ALTER TABLE answers DROP COLUMN IF EXISTS transcript;
+
+-- +goose StatementEnd
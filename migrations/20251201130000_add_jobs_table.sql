@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    type VARCHAR(64) NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    dedupe_key TEXT,
+    payload JSONB NOT NULL,
+
+    status VARCHAR(32) NOT NULL DEFAULT 'queued', -- queued, running, succeeded, failed
+    progress INTEGER NOT NULL DEFAULT 0,          -- 0-100
+    result JSONB,
+
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    last_error TEXT,
+
+    run_at TIMESTAMP NOT NULL DEFAULT NOW(),      -- следующая попытка не раньше этого времени
+    locked_until TIMESTAMP,                       -- аренда задачи воркером
+
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_jobs_status_run_at ON jobs(status, run_at);
+CREATE UNIQUE INDEX idx_jobs_active_dedupe_key ON jobs(dedupe_key) WHERE status IN ('queued', 'running');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_jobs_active_dedupe_key;
+DROP INDEX IF EXISTS idx_jobs_status_run_at;
+DROP TABLE IF EXISTS jobs;
+
+-- +goose StatementEnd
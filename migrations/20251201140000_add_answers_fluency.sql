@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE answers ADD COLUMN fluency JSONB;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE answers DROP COLUMN IF EXISTS fluency;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE answers ADD COLUMN content_type TEXT;
+ALTER TABLE answers ADD COLUMN size_bytes BIGINT;
+ALTER TABLE answers ADD COLUMN checksum TEXT;
+ALTER TABLE answers ADD COLUMN duration_ms INTEGER;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE answers DROP COLUMN IF EXISTS duration_ms;
+ALTER TABLE answers DROP COLUMN IF EXISTS checksum;
+ALTER TABLE answers DROP COLUMN IF EXISTS size_bytes;
+ALTER TABLE answers DROP COLUMN IF EXISTS content_type;
+
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Оставляем только последнюю запись на каждый вопрос сессии. Удаляемые записи сохраняются в answers_duplicates:
+-- их объекты в MinIO остаются в бакете, по minio_filename их можно удалить или восстановить вручную
+CREATE TABLE IF NOT EXISTS answers_duplicates AS
+SELECT a.*, NOW() AS removed_at FROM answers a
+WITH NO DATA;
+
+INSERT INTO answers_duplicates
+SELECT a.*, NOW()
+FROM answers a
+WHERE EXISTS (
+    SELECT 1 FROM answers newer
+    WHERE newer.session_id = a.session_id
+      AND newer.question_id = a.question_id
+      AND newer.id > a.id
+);
+
+DELETE FROM answers a
+USING answers newer
+WHERE a.session_id = newer.session_id
+  AND a.question_id = newer.question_id
+  AND a.id < newer.id;
+
+CREATE UNIQUE INDEX answers_session_question_idx ON answers (session_id, question_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Удалённые дубликаты не возвращаются в answers: таблица answers_duplicates остаётся, чтобы данные не пропали
+DROP INDEX IF EXISTS answers_session_question_idx;
+
+-- +goose StatementEnd
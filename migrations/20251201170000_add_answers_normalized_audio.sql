@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE answers ADD COLUMN normalized_filename TEXT UNIQUE;
+ALTER TABLE answers ADD COLUMN speech_duration_ms INTEGER;
+ALTER TABLE answers ADD COLUMN loudness_lufs DOUBLE PRECISION;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE answers DROP COLUMN IF EXISTS loudness_lufs;
+ALTER TABLE answers DROP COLUMN IF EXISTS speech_duration_ms;
+ALTER TABLE answers DROP COLUMN IF EXISTS normalized_filename;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE sessions ADD COLUMN prompt_name TEXT;
+ALTER TABLE sessions ADD COLUMN prompt_version TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS prompt_version;
+ALTER TABLE sessions DROP COLUMN IF EXISTS prompt_name;
+
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE users ADD COLUMN feedback_language TEXT NOT NULL DEFAULT 'en';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users DROP COLUMN IF EXISTS feedback_language;
+
+-- +goose StatementEnd
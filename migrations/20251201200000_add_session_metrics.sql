@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS session_metrics (
+    session_id UUID PRIMARY KEY REFERENCES sessions(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    overall_level VARCHAR(2) NOT NULL,
+    answers_count INTEGER NOT NULL,
+    grammar_issues INTEGER NOT NULL,
+    vocabulary JSONB NOT NULL DEFAULT '{}', -- количество top_words по уровням CEFR
+    words_per_minute DOUBLE PRECISION,
+    filler_rate DOUBLE PRECISION,
+    completed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_session_metrics_user_completed ON session_metrics(user_id, completed_at DESC);
+
+-- Метрики уже завершенных сессий восстанавливаются из сохраненного анализа
+INSERT INTO session_metrics (session_id, user_id, overall_level, answers_count, grammar_issues, vocabulary,
+                             words_per_minute, filler_rate, completed_at)
+SELECT s.id,
+       s.user_id,
+       s.analysis->>'overall_level',
+       (SELECT COUNT(*) FROM answers a WHERE a.session_id = s.id),
+       (SELECT COUNT(*) FROM jsonb_array_elements(arr.grammar_issues)),
+       COALESCE((SELECT jsonb_object_agg(v.level, v.words)
+                 FROM (SELECT w->>'level' AS level, COUNT(*) AS words
+                       FROM jsonb_array_elements(arr.top_words) w
+                       GROUP BY 1) v), '{}'),
+       (SELECT AVG((f->>'words_per_minute')::DOUBLE PRECISION) FROM jsonb_array_elements(arr.fluency) f),
+       (SELECT AVG((f->>'filler_rate')::DOUBLE PRECISION) FROM jsonb_array_elements(arr.fluency) f),
+       COALESCE(s.completed_at, s.created_at, NOW())
+FROM sessions s
+-- null вместо массива (пустой срез в Go) заменяется пустым массивом
+CROSS JOIN LATERAL (
+    SELECT CASE WHEN jsonb_typeof(s.analysis->'grammar_issues') = 'array'
+                THEN s.analysis->'grammar_issues' ELSE '[]' END AS grammar_issues,
+           CASE WHEN jsonb_typeof(s.analysis->'top_words') = 'array'
+                THEN s.analysis->'top_words' ELSE '[]' END AS top_words,
+           CASE WHEN jsonb_typeof(s.analysis->'fluency') = 'array'
+                THEN s.analysis->'fluency' ELSE '[]' END AS fluency
+) arr
+WHERE s.status = 'completed'
+  AND s.user_id IS NOT NULL
+  AND s.analysis->>'overall_level' IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2')
+ON CONFLICT (session_id) DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS session_metrics;
+
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS grammar_mistakes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    answer_id INTEGER REFERENCES answers(id) ON DELETE SET NULL,
+    category TEXT NOT NULL,
+    sentence TEXT NOT NULL,
+    corrected_sentence TEXT NOT NULL,
+    explanation TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_grammar_mistakes_user_category ON grammar_mistakes(user_id, category, created_at DESC);
+CREATE INDEX idx_grammar_mistakes_session ON grammar_mistakes(session_id);
+
+ALTER TABLE article_grammar_rules ADD COLUMN category TEXT;
+
+-- Категории существующих правил угадываются по названию, остальные размечаются вручную
+UPDATE article_grammar_rules SET category = CASE
+    WHEN name ILIKE '%article%' THEN 'articles'
+    WHEN name ILIKE '%preposition%' THEN 'prepositions'
+    WHEN name ILIKE '%agreement%' THEN 'subject_verb_agreement'
+    WHEN name ILIKE '%word order%' THEN 'word_order'
+    WHEN name ILIKE '%conditional%' THEN 'conditionals'
+    WHEN name ILIKE '%passive%' THEN 'passive_voice'
+    WHEN name ILIKE '%modal%' THEN 'modal_verbs'
+    WHEN name ILIKE '%comparative%' OR name ILIKE '%superlative%' THEN 'comparatives'
+    WHEN name ILIKE '%gerund%' OR name ILIKE '%infinitive%' THEN 'verb_forms'
+    WHEN name ILIKE '%pronoun%' THEN 'pronouns'
+    WHEN name ILIKE '%plural%' THEN 'plurals'
+    WHEN name ILIKE '%question%' THEN 'questions'
+    WHEN name ILIKE '%negat%' THEN 'negation'
+    WHEN name ILIKE '%tense%' OR name ILIKE '%present%' OR name ILIKE '%past%' OR name ILIKE '%future%'
+        OR name ILIKE '%perfect%' OR name ILIKE '%continuous%' THEN 'tenses'
+END;
+
+CREATE INDEX idx_grammar_category ON article_grammar_rules(category);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_grammar_category;
+ALTER TABLE article_grammar_rules DROP COLUMN IF EXISTS category;
+DROP TABLE IF EXISTS grammar_mistakes;
+
+-- +goose StatementEnd
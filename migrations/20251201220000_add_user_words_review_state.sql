@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Состояние SM-2: ease factor, текущий интервал и число подряд успешных повторений
+ALTER TABLE user_words ADD COLUMN ease_factor DOUBLE PRECISION NOT NULL DEFAULT 2.5;
+ALTER TABLE user_words ADD COLUMN interval_days INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE user_words ADD COLUMN repetitions INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE user_words ADD COLUMN lapses INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE user_words ADD COLUMN last_reviewed_at TIMESTAMP;
+
+-- Слова без даты повторения попадают в очередь сразу
+UPDATE user_words SET next_review_date = COALESCE(created_at, NOW()) WHERE next_review_date IS NULL;
+UPDATE user_words SET review_count = 0 WHERE review_count IS NULL;
+
+ALTER TABLE user_words ALTER COLUMN next_review_date SET NOT NULL;
+ALTER TABLE user_words ALTER COLUMN review_count SET NOT NULL;
+
+CREATE INDEX idx_user_words_collection_review ON user_words(collection_id, next_review_date);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_user_words_collection_review;
+
+ALTER TABLE user_words ALTER COLUMN review_count DROP NOT NULL;
+ALTER TABLE user_words ALTER COLUMN next_review_date DROP NOT NULL;
+
+ALTER TABLE user_words DROP COLUMN IF EXISTS last_reviewed_at;
+ALTER TABLE user_words DROP COLUMN IF EXISTS lapses;
+ALTER TABLE user_words DROP COLUMN IF EXISTS repetitions;
+ALTER TABLE user_words DROP COLUMN IF EXISTS interval_days;
+ALTER TABLE user_words DROP COLUMN IF EXISTS ease_factor;
+
+-- +goose StatementEnd
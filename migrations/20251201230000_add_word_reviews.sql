@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Дни занятий и серии считаются в часовом поясе пользователя
+ALTER TABLE users ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+-- Слово выучено, пока его интервал повторения не меньше порога
+ALTER TABLE user_words ADD COLUMN learned_at TIMESTAMP;
+
+ALTER TABLE word_collections ADD COLUMN last_study_date DATE;
+
+CREATE TABLE IF NOT EXISTS word_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    word_id UUID NOT NULL REFERENCES user_words(id) ON DELETE CASCADE,
+    collection_id UUID NOT NULL REFERENCES word_collections(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL,
+    grade SMALLINT NOT NULL,
+    ease_factor DOUBLE PRECISION NOT NULL,
+    interval_days INTEGER NOT NULL,
+    learned BOOLEAN NOT NULL,
+    study_date DATE NOT NULL, -- день повторения в часовом поясе пользователя
+    reviewed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_word_reviews_collection_date ON word_reviews(collection_id, study_date);
+CREATE INDEX idx_word_reviews_word ON word_reviews(word_id, reviewed_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_word_reviews_word;
+DROP INDEX IF EXISTS idx_word_reviews_collection_date;
+DROP TABLE IF EXISTS word_reviews;
+
+ALTER TABLE word_collections DROP COLUMN IF EXISTS last_study_date;
+ALTER TABLE user_words DROP COLUMN IF EXISTS learned_at;
+ALTER TABLE users DROP COLUMN IF EXISTS time_zone;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- До регистрации все коллекции создавались с user_id = 1, и первый зарегистрированный аккаунт получил бы их себе.
+-- Такие коллекции передаются служебному пользователю. Его пароль никому не известен, войти под ним нельзя
+INSERT INTO users (email, password_hash)
+VALUES ('legacy@speech-processing.invalid', '$2a$10$bf1mCTFH.Iu8VpiiwFyqNOxbgKQgzmPQHljytBDfre0/NgIO2GSEu');
+
+-- Коллекция без владельца или созданная раньше его регистрации осталась от общего пользователя
+UPDATE word_collections c
+SET user_id = (SELECT id FROM users WHERE email = 'legacy@speech-processing.invalid')
+WHERE NOT EXISTS (
+    SELECT 1 FROM users u
+    WHERE u.id = c.user_id AND u.created_at <= c.created_at
+);
+
+ALTER TABLE word_collections
+    ADD CONSTRAINT word_collections_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE word_collections DROP CONSTRAINT IF EXISTS word_collections_user_id_fkey;
+
+UPDATE word_collections
+SET user_id = 1
+WHERE user_id = (SELECT id FROM users WHERE email = 'legacy@speech-processing.invalid');
+
+DELETE FROM users WHERE email = 'legacy@speech-processing.invalid';
+
+-- +goose StatementEnd